@@ -0,0 +1,249 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// NotConverged is returned when an iterative calculation fails to converge within the
+// allowed number of iterations.
+var NotConverged = errors.New("graph: failed to converge")
+
+// Betweenness returns the betweenness centrality of each node in g, keyed by node ID, using
+// the algorithm of Brandes doi:10.1080/0022250X.2001.9990249. If weighted is true, edge weights
+// are used as path lengths and must be positive, otherwise each edge has length 1; a zero,
+// negative or NaN weight causes a panic. Parallel edges are counted as distinct shortest paths. If normalized is true, values are scaled by
+// the number of pairs of nodes not including the node, 2/((n-1)(n-2)).
+func Betweenness(g *Undirected, weighted, normalized bool) map[int]float64 {
+	return BetweennessPar(g, weighted, normalized, 1)
+}
+
+// BetweennessPar returns the betweenness centrality of each node in g as described for
+// Betweenness, spreading the single-source path calculations over threads goroutines. If
+// threads is less than 1, runtime.GOMAXPROCS(0) goroutines are used.
+func BetweennessPar(g *Undirected, weighted, normalized bool, threads int) map[int]float64 {
+	nb, _ := brandes(g, weighted, threads, true, false)
+
+	scale := 0.5
+	if n := float64(g.Order()); normalized && n > 2 {
+		scale = 1 / ((n - 1) * (n - 2))
+	}
+	b := make(map[int]float64, g.Order())
+	for _, n := range g.Nodes() {
+		b[n.ID()] = nb[n.ID()] * scale
+	}
+
+	return b
+}
+
+// EdgeBetweenness returns the betweenness centrality of each edge in g, keyed by edge ID, with
+// path lengths calculated as described for Betweenness. If normalized is true, values are scaled
+// by the number of pairs of nodes, 2/(n(n-1)).
+func EdgeBetweenness(g *Undirected, weighted, normalized bool) map[int]float64 {
+	return EdgeBetweennessPar(g, weighted, normalized, 1)
+}
+
+// EdgeBetweennessPar returns the betweenness centrality of each edge in g as described for
+// EdgeBetweenness, spreading the single-source path calculations over threads goroutines. If
+// threads is less than 1, runtime.GOMAXPROCS(0) goroutines are used.
+func EdgeBetweennessPar(g *Undirected, weighted, normalized bool, threads int) map[int]float64 {
+	_, eb := brandes(g, weighted, threads, false, true)
+
+	scale := 0.5
+	if n := float64(g.Order()); normalized && n > 1 {
+		scale = 1 / (n * (n - 1))
+	}
+	b := make(map[int]float64, g.Size())
+	for _, e := range g.Edges() {
+		b[e.ID()] = eb[e.ID()] * scale
+	}
+
+	return b
+}
+
+// brandes returns the unscaled node and edge betweenness sums for g, indexed by node and edge ID
+// respectively, accumulating over every source node. Each pair of nodes is counted twice.
+func brandes(g *Undirected, weighted bool, threads int, nodes, edges bool) (nb, eb []float64) {
+	// Check before starting workers so that a panic can be recovered by the caller.
+	checkLengths(g, weighted)
+	if threads < 1 {
+		threads = runtime.GOMAXPROCS(0)
+	}
+	sources := g.Nodes()
+	if threads > len(sources) {
+		threads = len(sources)
+	}
+	if threads < 1 {
+		threads = 1
+	}
+
+	type sums struct{ nb, eb []float64 }
+	part := make([]sums, threads)
+	var wg sync.WaitGroup
+	for i := range part {
+		part[i].nb = make([]float64, g.NextNodeID())
+		part[i].eb = make([]float64, g.NextEdgeID())
+		lo, hi := i*len(sources)/threads, (i+1)*len(sources)/threads
+		wg.Add(1)
+		go func(p sums, sources Nodes) {
+			defer wg.Done()
+			t := newPathTree(g, weighted)
+			delta := make([]float64, g.NextNodeID())
+			for _, s := range sources {
+				t.from(s)
+				for _, w := range t.order {
+					delta[w.ID()] = 0
+				}
+				for i := len(t.order) - 1; i >= 0; i-- {
+					w := t.order[i]
+					wid := w.ID()
+					for _, h := range t.pred[wid] {
+						vid := h.Node.ID()
+						c := t.sigma[vid] / t.sigma[wid] * (1 + delta[wid])
+						if edges {
+							p.eb[h.Edge.ID()] += c
+						}
+						delta[vid] += c
+					}
+					if nodes && w != s {
+						p.nb[wid] += delta[wid]
+					}
+				}
+			}
+		}(part[i], sources[lo:hi])
+	}
+	wg.Wait()
+
+	nb, eb = part[0].nb, part[0].eb
+	for _, p := range part[1:] {
+		for i, v := range p.nb {
+			nb[i] += v
+		}
+		for i, v := range p.eb {
+			eb[i] += v
+		}
+	}
+
+	return nb, eb
+}
+
+// Closeness returns the closeness centrality of each node in g, keyed by node ID, with path
+// lengths calculated as described for Betweenness. To handle disconnected graphs, the closeness
+// of a node is calculated within the set of r nodes reachable from it and scaled by the fraction
+// of other nodes that are reachable, following Wasserman and Faust, giving
+//
+//	(r-1)/(n-1) × (r-1)/Σd(u,v)
+//
+// for a node u. Isolated nodes have a closeness of zero.
+func Closeness(g *Undirected, weighted bool) map[int]float64 {
+	n := float64(g.Order())
+	t := newPathTree(g, weighted)
+	cc := make(map[int]float64, g.Order())
+	for _, u := range g.Nodes() {
+		t.from(u)
+		var sum float64
+		for _, v := range t.order {
+			sum += t.dist[v.ID()]
+		}
+		if sum == 0 {
+			cc[u.ID()] = 0
+			continue
+		}
+		r := float64(len(t.order))
+		cc[u.ID()] = (r - 1) / (n - 1) * (r - 1) / sum
+	}
+
+	return cc
+}
+
+// Harmonic returns the harmonic centrality of each node in g, keyed by node ID, the sum of the
+// reciprocal distances to all other nodes, with path lengths calculated as described for
+// Betweenness. Unreachable nodes contribute zero, so disconnected graphs are handled without
+// special treatment.
+func Harmonic(g *Undirected, weighted bool) map[int]float64 {
+	t := newPathTree(g, weighted)
+	hc := make(map[int]float64, g.Order())
+	for _, u := range g.Nodes() {
+		t.from(u)
+		var sum float64
+		for _, v := range t.order[1:] {
+			sum += 1 / t.dist[v.ID()]
+		}
+		hc[u.ID()] = sum
+	}
+
+	return hc
+}
+
+// EigenvectorCentrality returns the eigenvector centrality of each node in g, keyed by node ID,
+// calculated by power iteration on A+I, where A is the adjacency matrix of g as described for
+// AdjacencyMatrix, and normalized to unit Euclidean length. If weighted is false, each edge is
+// given a weight of 1. Iteration stops when the sum of absolute changes is less than n·tol. If
+// this is not reached within maxIter iterations, the last estimate is returned with the error
+// NotConverged. In a disconnected graph, the centralities of nodes outside the component with
+// the largest eigenvalue tend to zero.
+func EigenvectorCentrality(g *Undirected, weighted bool, tol float64, maxIter int) (map[int]float64, error) {
+	ix := NewNodeIndex(g)
+	n := ix.Len()
+	ec := make(map[int]float64, n)
+	if n == 0 {
+		return ec, nil
+	}
+
+	type arc struct {
+		u, v int
+		w    float64
+	}
+	arcs := make([]arc, 0, g.Size())
+	for _, e := range g.Edges() {
+		w := 1.
+		if weighted {
+			w = e.Weight()
+		}
+		arcs = append(arcs, arc{ix.Row(e.Tail().ID()), ix.Row(e.Head().ID()), w})
+	}
+
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	err := NotConverged
+	for i := 0; i < maxIter; i++ {
+		copy(next, x)
+		for _, a := range arcs {
+			next[a.u] += a.w * x[a.v]
+			next[a.v] += a.w * x[a.u]
+		}
+		var norm float64
+		for _, v := range next {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			norm = 1
+		}
+		var delta float64
+		for j, v := range next {
+			next[j] = v / norm
+			delta += math.Abs(next[j] - x[j])
+		}
+		x, next = next, x
+		if delta < float64(n)*tol {
+			err = nil
+			break
+		}
+	}
+
+	for i, v := range x {
+		ec[ix.ID(i)] = v
+	}
+
+	return ec, err
+}
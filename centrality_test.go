@@ -0,0 +1,99 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+
+	"gopkg.in/check.v1"
+)
+
+func floatsClose(a, b map[int]float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if math.Abs(v-b[k]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *S) TestBetweenness(c *check.C) {
+	// Values for the path 0-1-2-3-4.
+	g := Path(5)
+	c.Check(Betweenness(g, false, false), check.DeepEquals, map[int]float64{0: 0, 1: 3, 2: 4, 3: 3, 4: 0})
+	c.Check(floatsClose(Betweenness(g, false, true), map[int]float64{0: 0, 1: 0.5, 2: 4. / 6, 3: 0.5, 4: 0}, 1e-12), check.Equals, true)
+	c.Check(EdgeBetweenness(g, false, false), check.DeepEquals, map[int]float64{0: 4, 1: 6, 2: 6, 3: 4})
+
+	// The centre of a star lies on all paths between leaves.
+	c.Check(Betweenness(Star(4), false, true)[0], check.Equals, 1.)
+
+	// A heavy edge diverts paths around the square 0-1-2-3.
+	g = Cycle(4)
+	g.DeleteEdge(g.Edge(0))
+	g.ConnectWith(g.Node(0), g.Node(1), NewWeightedEdge(5))
+	b := Betweenness(g, true, false)
+	c.Check(b, check.DeepEquals, map[int]float64{0: 0, 1: 0, 2: 2, 3: 2})
+	c.Check(EdgeBetweenness(g, true, false)[4], check.Equals, 0.)
+
+	// Parallel evaluation gives the same result.
+	g = Barbell(5, 3)
+	b = Betweenness(g, false, true)
+	for _, threads := range []int{0, 2, 3, 100} {
+		c.Check(floatsClose(BetweennessPar(g, false, true, threads), b, 1e-12), check.Equals, true)
+		c.Check(floatsClose(EdgeBetweennessPar(g, false, true, threads), EdgeBetweenness(g, false, true), 1e-12), check.Equals, true)
+	}
+
+	// Path lengths must be positive.
+	for _, w := range []float64{0, -1, math.NaN()} {
+		g = reweight(Path(3), func(u, v int) float64 { return w })
+		c.Check(func() { BetweennessPar(g, true, false, 2) }, check.Panics, "graph: non-positive edge weight")
+		c.Check(func() { Closeness(g, true) }, check.Panics, "graph: non-positive edge weight")
+		c.Check(func() { Eccentricity(g, true) }, check.Panics, "graph: non-positive edge weight")
+		c.Check(Betweenness(g, false, false), check.DeepEquals, map[int]float64{0: 0, 1: 1, 2: 0})
+	}
+}
+
+func (s *S) TestCloseness(c *check.C) {
+	g := Path(3)
+	c.Check(floatsClose(Closeness(g, false), map[int]float64{0: 2. / 3, 1: 1, 2: 2. / 3}, 1e-12), check.Equals, true)
+	c.Check(floatsClose(Harmonic(g, false), map[int]float64{0: 1.5, 1: 2, 2: 1.5}, 1e-12), check.Equals, true)
+
+	// Add a disconnected edge and an isolated node.
+	g.AddID(3)
+	g.AddID(4)
+	g.AddID(5)
+	g.ConnectByID(3, 4)
+	cc := Closeness(g, false)
+	c.Check(cc[1], check.Equals, 2./5)
+	c.Check(cc[3], check.Equals, 1./5)
+	c.Check(cc[5], check.Equals, 0.)
+	h := Harmonic(g, false)
+	c.Check(h[3], check.Equals, 1.)
+	c.Check(h[5], check.Equals, 0.)
+
+	g = Cycle(4)
+	g.DeleteEdge(g.Edge(0))
+	g.ConnectWith(g.Node(0), g.Node(1), NewWeightedEdge(5))
+	c.Check(Closeness(g, true)[0], check.Equals, 3./6)
+}
+
+func (s *S) TestEigenvectorCentrality(c *check.C) {
+	ec, err := EigenvectorCentrality(Cycle(5), false, 1e-9, 100)
+	c.Assert(err, check.Equals, nil)
+	for _, v := range ec {
+		c.Check(math.Abs(v-1/math.Sqrt(5)) < 1e-6, check.Equals, true)
+	}
+
+	ec, err = EigenvectorCentrality(Star(4), false, 1e-9, 1000)
+	c.Assert(err, check.Equals, nil)
+	// The principal eigenvector of a star with k leaves is proportional to (√k, 1, …, 1).
+	c.Check(math.Abs(ec[0]/ec[1]-2) < 1e-6, check.Equals, true)
+
+	_, err = EigenvectorCentrality(Star(4), false, 1e-12, 2)
+	c.Check(err, check.Equals, NotConverged)
+}
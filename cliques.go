@@ -0,0 +1,291 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"sort"
+)
+
+// MaximalCliques calls fn with each maximal clique of g, enumerated by the Bron–Kerbosch
+// algorithm with pivoting over a degeneracy ordering of the nodes. See Eppstein, Löffler and
+// Strash doi:10.1007/978-3-642-17517-6_36. Self-loops and parallel edges are ignored, so each
+// isolated node is a maximal clique. The slice passed to fn is not reused. If fn returns true,
+// the enumeration is stopped.
+func MaximalCliques(g *Undirected, fn func(Nodes) bool) {
+	ix := NewNodeIndex(g)
+	bk := &bronKerbosch{
+		g:   g,
+		ix:  ix,
+		adj: simpleAdjacency(g, ix),
+		fn:  fn,
+	}
+	bk.run()
+}
+
+// CliqueNumber returns the number of nodes in the largest clique of g.
+func CliqueNumber(g *Undirected) int {
+	return len(MaximumClique(g))
+}
+
+// MaximumClique returns a largest clique of g. If g has no nodes, MaximumClique returns nil.
+func MaximumClique(g *Undirected) Nodes {
+	var max Nodes
+	ix := NewNodeIndex(g)
+	bk := &bronKerbosch{
+		g:     g,
+		ix:    ix,
+		adj:   simpleAdjacency(g, ix),
+		bound: true,
+	}
+	bk.fn = func(c Nodes) bool {
+		if len(c) > len(max) {
+			max = c
+			bk.best = len(c)
+		}
+		return false
+	}
+	bk.run()
+
+	return max
+}
+
+type bronKerbosch struct {
+	g   *Undirected
+	ix  *NodeIndex
+	adj [][]int
+	fn  func(Nodes) bool
+
+	// If bound is true, branches that cannot give a clique
+	// larger than best are not explored.
+	bound bool
+	best  int
+
+	stop bool
+}
+
+func (bk *bronKerbosch) run() {
+	order := degeneracyOrder(bk.adj)
+	pos := make([]int, len(order))
+	for i, v := range order {
+		pos[v] = i
+	}
+	for _, v := range order {
+		var p, x []int
+		for _, u := range bk.adj[v] {
+			if pos[u] > pos[v] {
+				p = append(p, u)
+			} else {
+				x = append(x, u)
+			}
+		}
+		bk.search([]int{v}, p, x)
+		if bk.stop {
+			return
+		}
+	}
+}
+
+func (bk *bronKerbosch) search(r, p, x []int) {
+	if len(p) == 0 {
+		if len(x) == 0 && (!bk.bound || len(r) > bk.best) {
+			c := make(Nodes, len(r))
+			for i, v := range r {
+				c[i] = bk.g.Node(bk.ix.ID(v))
+			}
+			bk.stop = bk.fn(c)
+		}
+		return
+	}
+	if bk.bound && len(r)+len(p) <= bk.best {
+		return
+	}
+
+	// Choose the pivot with the most neighbors in p to
+	// minimize the number of branches.
+	pivot, max := -1, -1
+	for _, set := range [...][]int{p, x} {
+		for _, u := range set {
+			if n := countIntersect(p, bk.adj[u]); n > max {
+				pivot, max = u, n
+			}
+		}
+	}
+
+	candidates := difference(p, bk.adj[pivot])
+	for _, v := range candidates {
+		nv := bk.adj[v]
+		bk.search(append(r[:len(r):len(r)], v), intersect(p, nv), intersect(x, nv))
+		if bk.stop {
+			return
+		}
+		p = remove(p, v)
+		x = insert(x, v)
+	}
+}
+
+// simpleAdjacency returns the sorted adjacency lists of the rows of ix, ignoring self-loops and
+// collapsing parallel edges.
+func simpleAdjacency(g *Undirected, ix *NodeIndex) [][]int {
+	adj := make([][]int, ix.Len())
+	for _, e := range g.Edges() {
+		u, v := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		if u == v {
+			continue
+		}
+		adj[u] = append(adj[u], v)
+		adj[v] = append(adj[v], u)
+	}
+	for i, a := range adj {
+		sort.Ints(a)
+		var k int
+		for _, v := range a {
+			if k > 0 && a[k-1] == v {
+				continue
+			}
+			a[k] = v
+			k++
+		}
+		adj[i] = a[:k]
+	}
+
+	return adj
+}
+
+// degeneracyOrder returns the nodes of the graph described by adj in degeneracy order, the
+// order in which they are removed by repeatedly removing a node of minimum degree.
+func degeneracyOrder(adj [][]int) []int {
+	order, _ := cores(adj, func(v int) int { return len(adj[v]) })
+	return order
+}
+
+// cores returns the nodes of the graph described by adj in the order in which they are removed
+// by repeatedly removing a node of minimum remaining degree, and the core number of each node,
+// using the bucket algorithm of Batagelj and Zaveršnik arXiv:cs/0310049. The initial degree of
+// each node is given by deg and is reduced by one for each neighbor listed in adj that is
+// removed before the node.
+func cores(adj [][]int, deg func(int) int) (order, core []int) {
+	n := len(adj)
+	d := make([]int, n)
+	var max int
+	for v := range d {
+		d[v] = deg(v)
+		if d[v] > max {
+			max = d[v]
+		}
+	}
+
+	// Sort nodes by degree into vert, with bin[k] holding
+	// the start of the nodes of degree k.
+	bin := make([]int, max+1)
+	for _, k := range d {
+		bin[k]++
+	}
+	for k, start := 0, 0; k <= max; k++ {
+		start, bin[k] = start+bin[k], start
+	}
+	vert := make([]int, n)
+	pos := make([]int, n)
+	for v, k := range d {
+		pos[v] = bin[k]
+		vert[pos[v]] = v
+		bin[k]++
+	}
+	for k := max; k > 0; k-- {
+		bin[k] = bin[k-1]
+	}
+	bin[0] = 0
+
+	for i := 0; i < n; i++ {
+		v := vert[i]
+		for _, u := range adj[v] {
+			if d[u] <= d[v] {
+				continue
+			}
+			// Move u to the start of its bin and
+			// shift the bin boundary past it.
+			du, pu := d[u], pos[u]
+			pw := bin[du]
+			w := vert[pw]
+			if u != w {
+				pos[u], pos[w] = pw, pu
+				vert[pu], vert[pw] = w, u
+			}
+			bin[du]++
+			d[u]--
+		}
+	}
+
+	return vert, d
+}
+
+// countIntersect returns the number of elements common to the sorted slices a and b.
+func countIntersect(a, b []int) int {
+	var n int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
+	}
+	return n
+}
+
+// intersect returns the elements common to the sorted slices a and b.
+func intersect(a, b []int) []int {
+	var c []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			c = append(c, a[i])
+			i++
+			j++
+		}
+	}
+	return c
+}
+
+// difference returns the elements of the sorted slice a that are not in the sorted slice b.
+func difference(a, b []int) []int {
+	var c []int
+	j := 0
+	for _, v := range a {
+		for j < len(b) && b[j] < v {
+			j++
+		}
+		if j < len(b) && b[j] == v {
+			continue
+		}
+		c = append(c, v)
+	}
+	return c
+}
+
+// remove returns the sorted slice a without v, reusing a.
+func remove(a []int, v int) []int {
+	i := sort.SearchInts(a, v)
+	if i < len(a) && a[i] == v {
+		a = append(a[:i], a[i+1:]...)
+	}
+	return a
+}
+
+// insert returns the sorted slice a with v added.
+func insert(a []int, v int) []int {
+	i := sort.SearchInts(a, v)
+	a = append(a, 0)
+	copy(a[i+1:], a[i:])
+	a[i] = v
+	return a
+}
@@ -0,0 +1,116 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math/rand"
+	"sort"
+
+	"gopkg.in/check.v1"
+)
+
+// cliqueIDs returns the sorted node IDs of each clique, with cliques sorted.
+func cliqueIDs(cs []Nodes) [][]int {
+	var ids [][]int
+	for _, c := range cs {
+		var id []int
+		for _, n := range c {
+			id = append(id, n.ID())
+		}
+		sort.Ints(id)
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return ids
+}
+
+// isClique returns whether the nodes in c are pairwise connected in g.
+func isClique(g *Undirected, c Nodes) bool {
+	for i, u := range c {
+		for _, v := range c[i+1:] {
+			if ok, _ := g.Connected(u, v); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *S) TestMaximalCliques(c *check.C) {
+	g := Barbell(4, 1)
+	g.ConnectByID(4, 4)
+	g.ConnectByID(0, 1)
+	g.AddID(9)
+	var cs []Nodes
+	MaximalCliques(g, func(c Nodes) bool {
+		cs = append(cs, c)
+		return false
+	})
+	c.Check(cliqueIDs(cs), check.DeepEquals, [][]int{{0, 1, 2, 3}, {3, 4}, {4, 5}, {5, 6, 7, 8}, {9}})
+
+	var n int
+	MaximalCliques(g, func(c Nodes) bool {
+		n++
+		return n == 2
+	})
+	c.Check(n, check.Equals, 2)
+
+	c.Check(CliqueNumber(g), check.Equals, 4)
+	c.Check(CliqueNumber(Petersen()), check.Equals, 2)
+	c.Check(CliqueNumber(NewUndirected()), check.Equals, 0)
+	c.Check(MaximumClique(NewUndirected()), check.IsNil)
+}
+
+func (s *S) TestMaximalCliquesRandom(c *check.C) {
+	// Check against brute force enumeration of subsets.
+	const n = 12
+	g := GnP(n, 0.5, rand.New(rand.NewSource(1)))
+	var want []Nodes
+	nodes := g.Nodes()
+	for set := 1; set < 1<<n; set++ {
+		var c Nodes
+		for i := 0; i < n; i++ {
+			if set&(1<<uint(i)) != 0 {
+				c = append(c, nodes[i])
+			}
+		}
+		if !isClique(g, c) {
+			continue
+		}
+		maximal := true
+		for i := 0; i < n && maximal; i++ {
+			if set&(1<<uint(i)) == 0 && isClique(g, append(c[:len(c):len(c)], nodes[i])) {
+				maximal = false
+			}
+		}
+		if maximal {
+			want = append(want, c)
+		}
+	}
+
+	var got []Nodes
+	MaximalCliques(g, func(c Nodes) bool {
+		got = append(got, c)
+		return false
+	})
+	c.Check(cliqueIDs(got), check.DeepEquals, cliqueIDs(want))
+
+	max := 0
+	for _, c := range want {
+		if len(c) > max {
+			max = len(c)
+		}
+	}
+	c.Check(CliqueNumber(g), check.Equals, max)
+	c.Check(isClique(g, MaximumClique(g)), check.Equals, true)
+}
@@ -0,0 +1,462 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math/rand"
+)
+
+// Modularity returns the modularity of the partition of g described by communities, which maps
+// node IDs to community labels, with the given resolution. Edge weights are taken from Weight
+// and self-loops contribute twice their weight to the internal weight of a community, as for
+// AdjacencyMatrix. Nodes not present in communities are each treated as a singleton community.
+func Modularity(g *Undirected, communities map[int]int, resolution float64) float64 {
+	type label struct {
+		c      int
+		single bool
+	}
+	labelOf := func(n Node) label {
+		if c, ok := communities[n.ID()]; ok {
+			return label{c: c}
+		}
+		return label{c: n.ID(), single: true}
+	}
+
+	// Community totals are held in order of first appearance
+	// so that the result does not depend on map iteration order.
+	index := make(map[label]int)
+	var in, tot []float64
+	row := func(l label) int {
+		i, ok := index[l]
+		if !ok {
+			i = len(tot)
+			index[l] = i
+			in = append(in, 0)
+			tot = append(tot, 0)
+		}
+		return i
+	}
+	var m2 float64
+	for _, e := range g.Edges() {
+		w := e.Weight()
+		cu, cv := row(labelOf(e.Tail())), row(labelOf(e.Head()))
+		tot[cu] += w
+		tot[cv] += w
+		if cu == cv {
+			in[cu] += 2 * w
+		}
+		m2 += 2 * w
+	}
+	if m2 == 0 {
+		return 0
+	}
+
+	var q float64
+	for c, t := range tot {
+		q += in[c]/m2 - resolution*(t/m2)*(t/m2)
+	}
+
+	return q
+}
+
+// Louvain returns a partition of g, mapping node IDs to community labels in [0, k), found by
+// Louvain modularity optimization with the given resolution, and the modularity of the partition.
+// See Blondel et al. doi:10.1088/1742-5468/2008/10/P10008. Edge weights are taken from Weight.
+// If rnd is not nil, it is used to randomize the order in which nodes are considered.
+func Louvain(g *Undirected, resolution float64, rnd *rand.Rand) (map[int]int, float64) {
+	ix := NewNodeIndex(g)
+	h := newModGraph(g, ix)
+
+	// super holds the node of the current aggregate graph
+	// that each row of ix has been merged into.
+	super := make([]int, ix.Len())
+	for i := range super {
+		super[i] = i
+	}
+	for {
+		comm := singletons(len(h.adj))
+		if !h.moveNodes(comm, resolution, rnd) {
+			break
+		}
+		k := renumber(comm)
+		for i, s := range super {
+			super[i] = comm[s]
+		}
+		h = h.aggregate(comm, k)
+	}
+
+	return h.result(g, ix, super, resolution)
+}
+
+// Leiden returns a partition of g, mapping node IDs to community labels in [0, k), found by
+// Leiden modularity optimization with the given resolution, and the modularity of the partition.
+// See Traag et al. doi:10.1038/s41598-019-41695-z. Leiden adds a refinement phase to Louvain
+// that prevents the poorly connected communities that Louvain can produce. Edge weights are
+// taken from Weight. If rnd is not nil, it is used to randomize the order in which nodes are
+// considered.
+func Leiden(g *Undirected, resolution float64, rnd *rand.Rand) (map[int]int, float64) {
+	ix := NewNodeIndex(g)
+	h := newModGraph(g, ix)
+
+	super := make([]int, ix.Len())
+	for i := range super {
+		super[i] = i
+	}
+	comm := singletons(len(h.adj))
+	for {
+		h.moveNodesFast(comm, resolution, rnd)
+		k := renumber(comm)
+		if k == len(h.adj) {
+			break
+		}
+
+		refined := h.refine(comm, k, resolution, rnd)
+		kr := renumber(refined)
+		if kr == len(h.adj) {
+			// Nothing could be merged during refinement, so
+			// aggregate using the unrefined partition.
+			copy(refined, comm)
+			kr = k
+		}
+		for i, s := range super {
+			super[i] = refined[s]
+		}
+
+		// The aggregate graph is built from the refined partition
+		// but its nodes start in their unrefined communities.
+		next := make([]int, kr)
+		for v, r := range refined {
+			next[r] = comm[v]
+		}
+		h = h.aggregate(refined, kr)
+		comm = next
+	}
+	for i, s := range super {
+		super[i] = comm[s]
+	}
+
+	return h.result(g, ix, super, resolution)
+}
+
+// modGraph is a compact weighted graph used for modularity optimization. Parallel edges are
+// merged, and self-loop weights are held separately.
+type modGraph struct {
+	adj   [][]wHop
+	loops []float64 // diagonal adjacency values
+	deg   []float64 // weighted degree, including loops
+	m2    float64   // sum of weighted degrees
+}
+
+type wHop struct {
+	to int
+	w  float64
+}
+
+func newModGraph(g *Undirected, ix *NodeIndex) *modGraph {
+	n := ix.Len()
+	h := &modGraph{
+		adj:   make([][]wHop, n),
+		loops: make([]float64, n),
+		deg:   make([]float64, n),
+	}
+	pos := make([]map[int]int, n)
+	for _, e := range g.Edges() {
+		u, v := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		h.connect(pos, u, v, e.Weight())
+	}
+
+	return h
+}
+
+// connect adds weight w between u and v, recording the position of v in the adjacency of u
+// in pos to merge parallel edges.
+func (h *modGraph) connect(pos []map[int]int, u, v int, w float64) {
+	h.deg[u] += w
+	h.deg[v] += w
+	h.m2 += 2 * w
+	if u == v {
+		h.loops[u] += 2 * w
+		return
+	}
+	for _, p := range [...][2]int{{u, v}, {v, u}} {
+		a, b := p[0], p[1]
+		if pos[a] == nil {
+			pos[a] = make(map[int]int)
+		}
+		if i, ok := pos[a][b]; ok {
+			h.adj[a][i].w += w
+		} else {
+			pos[a][b] = len(h.adj[a])
+			h.adj[a] = append(h.adj[a], wHop{to: b, w: w})
+		}
+	}
+}
+
+// aggregate returns the graph formed by merging the nodes of h in each of the k communities.
+func (h *modGraph) aggregate(comm []int, k int) *modGraph {
+	a := &modGraph{
+		adj:   make([][]wHop, k),
+		loops: make([]float64, k),
+		deg:   make([]float64, k),
+	}
+	pos := make([]map[int]int, k)
+	for u, hops := range h.adj {
+		cu := comm[u]
+		if h.loops[u] != 0 {
+			a.connect(pos, cu, cu, h.loops[u]/2)
+		}
+		for _, hp := range hops {
+			if u < hp.to {
+				a.connect(pos, cu, comm[hp.to], hp.w)
+			}
+		}
+	}
+
+	return a
+}
+
+// order returns the nodes of h, shuffled if rnd is not nil.
+func (h *modGraph) order(rnd *rand.Rand) []int {
+	o := make([]int, len(h.adj))
+	for i := range o {
+		o[i] = i
+	}
+	if rnd != nil {
+		rnd.Shuffle(len(o), func(i, j int) { o[i], o[j] = o[j], o[i] })
+	}
+	return o
+}
+
+// mover holds the state for moving nodes between communities.
+type mover struct {
+	h          *modGraph
+	comm       []int
+	tot        []float64
+	resolution float64
+
+	// Scratch for the weights from a node to its
+	// neighboring communities.
+	weight []float64
+	seen   []int
+}
+
+func newMover(h *modGraph, comm []int, resolution float64) *mover {
+	m := &mover{
+		h:          h,
+		comm:       comm,
+		tot:        make([]float64, len(h.adj)),
+		resolution: resolution,
+		weight:     make([]float64, len(h.adj)),
+	}
+	for v, c := range comm {
+		m.tot[c] += h.deg[v]
+	}
+	return m
+}
+
+// best moves v to the community that gives the greatest modularity gain and returns whether
+// the community of v changed.
+func (m *mover) best(v int) bool {
+	h := m.h
+	from := m.comm[v]
+	m.seen = m.seen[:0]
+	m.weight[from] = 0
+	m.seen = append(m.seen, from)
+	for _, hp := range h.adj[v] {
+		c := m.comm[hp.to]
+		if m.weight[c] == 0 && c != from {
+			m.seen = append(m.seen, c)
+		}
+		m.weight[c] += hp.w
+	}
+
+	m.tot[from] -= h.deg[v]
+	kv := h.deg[v] / h.m2
+	to, gain := from, m.weight[from]-m.resolution*m.tot[from]*kv
+	for _, c := range m.seen[1:] {
+		if g := m.weight[c] - m.resolution*m.tot[c]*kv; g > gain {
+			to, gain = c, g
+		}
+	}
+	for _, c := range m.seen {
+		m.weight[c] = 0
+	}
+	m.tot[to] += h.deg[v]
+	m.comm[v] = to
+
+	return to != from
+}
+
+// moveNodes performs Louvain local moving of the nodes of h, repeatedly sweeping over all nodes
+// until no node changes community. It returns whether any node was moved.
+func (h *modGraph) moveNodes(comm []int, resolution float64, rnd *rand.Rand) bool {
+	if h.m2 == 0 {
+		return false
+	}
+	m := newMover(h, comm, resolution)
+	order := h.order(rnd)
+	var moved bool
+	for {
+		var changed bool
+		for _, v := range order {
+			if m.best(v) {
+				changed = true
+			}
+		}
+		if !changed {
+			return moved
+		}
+		moved = true
+	}
+}
+
+// moveNodesFast performs Leiden queue-based local moving of the nodes of h, revisiting only the
+// neighbors of moved nodes.
+func (h *modGraph) moveNodesFast(comm []int, resolution float64, rnd *rand.Rand) {
+	if h.m2 == 0 {
+		return
+	}
+	m := newMover(h, comm, resolution)
+	order := h.order(rnd)
+	queued := make([]bool, len(h.adj))
+	q := make([]int, 0, len(order))
+	for _, v := range order {
+		q = append(q, v)
+		queued[v] = true
+	}
+	for len(q) > 0 {
+		v := q[0]
+		q = q[1:]
+		queued[v] = false
+		if !m.best(v) {
+			continue
+		}
+		for _, hp := range h.adj[v] {
+			if u := hp.to; !queued[u] && comm[u] != comm[v] {
+				q = append(q, u)
+				queued[u] = true
+			}
+		}
+	}
+}
+
+// refine returns the Leiden refinement of the partition comm of h into k communities. Each
+// community is split into well-connected subcommunities by merging singleton nodes into
+// subcommunities within the same community.
+func (h *modGraph) refine(comm []int, k int, resolution float64, rnd *rand.Rand) []int {
+	n := len(h.adj)
+	refined := singletons(n)
+	if h.m2 == 0 {
+		return refined
+	}
+
+	ctot := make([]float64, k)
+	for v, c := range comm {
+		ctot[c] += h.deg[v]
+	}
+
+	// tot and ext hold the total degree of each refined community
+	// and its weight to the remainder of its community.
+	tot := make([]float64, n)
+	ext := make([]float64, n)
+	size := make([]int, n)
+	for v := range h.adj {
+		tot[v] = h.deg[v]
+		size[v] = 1
+		for _, hp := range h.adj[v] {
+			if comm[hp.to] == comm[v] {
+				ext[v] += hp.w
+			}
+		}
+	}
+
+	weight := make([]float64, n)
+	var seen []int
+	for _, v := range h.order(rnd) {
+		if size[refined[v]] != 1 {
+			continue
+		}
+		c := comm[v]
+		if ext[v] < resolution*h.deg[v]*(ctot[c]-h.deg[v])/h.m2 {
+			continue
+		}
+
+		seen = seen[:0]
+		for _, hp := range h.adj[v] {
+			if comm[hp.to] != c {
+				continue
+			}
+			r := refined[hp.to]
+			if weight[r] == 0 {
+				seen = append(seen, r)
+			}
+			weight[r] += hp.w
+		}
+
+		kv := h.deg[v] / h.m2
+		to, gain := refined[v], 0.
+		for _, r := range seen {
+			if ext[r] < resolution*tot[r]*(ctot[c]-tot[r])/h.m2 {
+				continue
+			}
+			if g := weight[r] - resolution*tot[r]*kv; g > gain {
+				to, gain = r, g
+			}
+		}
+		if to != refined[v] {
+			from := refined[v]
+			ext[to] += ext[from] - 2*weight[to]
+			tot[to] += tot[from]
+			size[to] += size[from]
+			size[from] = 0
+			refined[v] = to
+		}
+		for _, r := range seen {
+			weight[r] = 0
+		}
+	}
+
+	return refined
+}
+
+// result returns the partition of the nodes of g described by super, the aggregate node of each
+// row of ix, relabelled in order of ascending node ID, and its modularity.
+func (h *modGraph) result(g *Undirected, ix *NodeIndex, super []int, resolution float64) (map[int]int, float64) {
+	label := make(map[int]int)
+	communities := make(map[int]int, len(super))
+	for i, s := range super {
+		l, ok := label[s]
+		if !ok {
+			l = len(label)
+			label[s] = l
+		}
+		communities[ix.ID(i)] = l
+	}
+
+	return communities, Modularity(g, communities, resolution)
+}
+
+// singletons returns a partition of n nodes into n communities.
+func singletons(n int) []int {
+	c := make([]int, n)
+	for i := range c {
+		c[i] = i
+	}
+	return c
+}
+
+// renumber relabels the communities in c to [0, k) in order of first appearance and returns k.
+func renumber(c []int) int {
+	label := make(map[int]int)
+	for i, l := range c {
+		r, ok := label[l]
+		if !ok {
+			r = len(label)
+			label[l] = r
+		}
+		c[i] = r
+	}
+	return len(label)
+}
@@ -0,0 +1,101 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+
+	"gopkg.in/check.v1"
+)
+
+// ringOfCliques returns n cliques of k nodes joined in a ring by single edges.
+func ringOfCliques(n, k int) *Undirected {
+	g := NewUndirected()
+	for i := 0; i < n*k; i++ {
+		g.AddID(i)
+	}
+	for c := 0; c < n; c++ {
+		for u := c * k; u < (c+1)*k; u++ {
+			for v := u + 1; v < (c+1)*k; v++ {
+				g.ConnectByID(u, v)
+			}
+		}
+		g.ConnectByID(c*k, ((c+1)*k+1)%(n*k))
+	}
+	return g
+}
+
+func (s *S) TestModularity(c *check.C) {
+	// Two triangles joined by an edge.
+	g := Barbell(3, 0)
+	comm := map[int]int{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 5: 1}
+	c.Check(math.Abs(Modularity(g, comm, 1)-5./14) < 1e-12, check.Equals, true)
+	c.Check(Modularity(g, map[int]int{0: 0, 1: 0, 2: 0, 3: 0, 4: 0, 5: 0}, 1), check.Equals, 0.)
+
+	// Missing nodes are singletons.
+	single := make(map[int]int)
+	for i := 0; i < 6; i++ {
+		single[i] = i
+	}
+	c.Check(math.Abs(Modularity(g, nil, 1)-Modularity(g, single, 1)) < 1e-12, check.Equals, true)
+}
+
+func (s *S) TestLouvainLeiden(c *check.C) {
+	g := ringOfCliques(8, 5)
+	for _, f := range []func(*Undirected, float64, *rand.Rand) (map[int]int, float64){Louvain, Leiden} {
+		for _, rnd := range []*rand.Rand{nil, rand.New(rand.NewSource(1))} {
+			comm, q := f(g, 1, rnd)
+			c.Check(len(comm), check.Equals, g.Order())
+			c.Check(q, check.Equals, Modularity(g, comm, 1))
+			for u := 0; u < g.Order(); u++ {
+				c.Check(comm[u], check.Equals, comm[u/5*5], check.Commentf("node %d", u))
+			}
+			labels := make(map[int]bool)
+			for _, l := range comm {
+				labels[l] = true
+			}
+			c.Check(labels, check.HasLen, 8)
+		}
+	}
+
+	// Weighted communities.
+	g = Cycle(6)
+	for _, id := range []int{0, 2, 4} {
+		e := g.Edge(id)
+		u, v := e.Nodes()
+		g.DeleteEdge(e)
+		g.ConnectWith(u, v, NewWeightedEdge(10))
+	}
+	for _, f := range []func(*Undirected, float64, *rand.Rand) (map[int]int, float64){Louvain, Leiden} {
+		comm, _ := f(g, 1, nil)
+		c.Check(comm[0], check.Equals, comm[1])
+		c.Check(comm[2], check.Equals, comm[3])
+		c.Check(comm[1], check.Not(check.Equals), comm[2])
+	}
+
+	// Edgeless graphs give singleton communities.
+	comm, q := Leiden(withOrder(3), 1, nil)
+	c.Check(comm, check.DeepEquals, map[int]int{0: 0, 1: 1, 2: 2})
+	c.Check(q, check.Equals, 0.)
+}
+
+func (s *S) TestLeidenLarger(c *check.C) {
+	sizes := []int{30, 30, 30, 30}
+	p := [][]float64{
+		{0.5, 0.01, 0.01, 0.01},
+		{0.01, 0.5, 0.01, 0.01},
+		{0.01, 0.01, 0.5, 0.01},
+		{0.01, 0.01, 0.01, 0.5},
+	}
+	g := StochasticBlock(sizes, p, rand.New(rand.NewSource(1)))
+	_, ql := Louvain(g, 1, rand.New(rand.NewSource(2)))
+	comm, q := Leiden(g, 1, rand.New(rand.NewSource(2)))
+	c.Check(q > 0.6, check.Equals, true, check.Commentf("q=%v", q))
+	c.Check(q >= ql-0.01, check.Equals, true, check.Commentf("leiden=%v louvain=%v", q, ql))
+	for u := 0; u < g.Order(); u++ {
+		c.Check(comm[u], check.Equals, comm[u/30*30])
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+// CoreNumbers returns the core number of each node in g, keyed by node ID. The core number of a
+// node is the greatest k such that the node belongs to the k-core of g, the maximal subgraph in
+// which every node has a degree of at least k. Degrees follow Node.Degree, so parallel edges are
+// counted individually and self-loops are counted twice. The decomposition takes O(|V|+|E|)
+// time.
+func CoreNumbers(g *Undirected) map[int]int {
+	ix := NewNodeIndex(g)
+	_, core := multiCores(g, ix)
+	cn := make(map[int]int, len(core))
+	for i, k := range core {
+		cn[ix.ID(i)] = k
+	}
+
+	return cn
+}
+
+// DegeneracyOrdering returns the nodes of g in a degeneracy ordering, the order in which they
+// are removed by repeatedly removing a node of minimum remaining degree. Degrees follow
+// Node.Degree. Each node has at most k neighbors later in the ordering, where k is the
+// degeneracy of g, the greatest core number of its nodes.
+func DegeneracyOrdering(g *Undirected) Nodes {
+	ix := NewNodeIndex(g)
+	order, _ := multiCores(g, ix)
+	ns := make(Nodes, len(order))
+	for i, v := range order {
+		ns[i] = g.Node(ix.ID(v))
+	}
+
+	return ns
+}
+
+// KCore returns the k-core of g as a new graph, the subgraph induced by the nodes with a core
+// number of at least k. Node and edge IDs and edge weights are retained from g.
+func KCore(g *Undirected, k int) *Undirected {
+	ix := NewNodeIndex(g)
+	_, core := multiCores(g, ix)
+
+	c := NewUndirected()
+	for i, kv := range core {
+		if kv >= k {
+			c.AddID(ix.ID(i))
+		}
+	}
+	for _, e := range g.Edges() {
+		uid, vid := e.Tail().ID(), e.Head().ID()
+		if core[ix.Row(uid)] < k || core[ix.Row(vid)] < k {
+			continue
+		}
+		ne := c.newEdgeKeepID(e.ID(), c.nodes[uid], c.nodes[vid], e.Weight())
+		c.nodes[uid].add(ne)
+		if vid != uid {
+			c.nodes[vid].add(ne)
+		}
+	}
+
+	return c
+}
+
+// multiCores returns the removal order and core numbers of the rows of ix, using the degrees of
+// the nodes of g.
+func multiCores(g *Undirected, ix *NodeIndex) (order, core []int) {
+	// Each edge is listed once at each end. Self-loops are
+	// not listed since they are only lost with their node.
+	adj := make([][]int, ix.Len())
+	for _, e := range g.Edges() {
+		u, v := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		if u == v {
+			continue
+		}
+		adj[u] = append(adj[u], v)
+		adj[v] = append(adj[v], u)
+	}
+
+	return cores(adj, func(v int) int { return g.Node(ix.ID(v)).Degree() })
+}
@@ -0,0 +1,86 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math/rand"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestCoreNumbers(c *check.C) {
+	// A 4-clique with a pendant path and a looped
+	// isolated node.
+	g := Complete(4)
+	g.AddID(4)
+	g.AddID(5)
+	g.ConnectByID(3, 4)
+	g.ConnectByID(4, 5)
+	g.AddID(6)
+	g.ConnectByID(6, 6)
+	g.AddID(7)
+	c.Check(CoreNumbers(g), check.DeepEquals, map[int]int{0: 3, 1: 3, 2: 3, 3: 3, 4: 1, 5: 1, 6: 2, 7: 0})
+
+	// Parallel edges count individually.
+	p := Path(3)
+	p.ConnectByID(0, 1)
+	p.ConnectByID(1, 2)
+	c.Check(CoreNumbers(p), check.DeepEquals, map[int]int{0: 2, 1: 2, 2: 2})
+
+	c.Check(CoreNumbers(Petersen()), check.DeepEquals, map[int]int{0: 3, 1: 3, 2: 3, 3: 3, 4: 3, 5: 3, 6: 3, 7: 3, 8: 3, 9: 3})
+}
+
+func (s *S) TestDegeneracyOrdering(c *check.C) {
+	g := BarabasiAlbert(100, 3, rand.New(rand.NewSource(1)))
+	cn := CoreNumbers(g)
+	var k int
+	for _, v := range cn {
+		if v > k {
+			k = v
+		}
+	}
+
+	order := DegeneracyOrdering(g)
+	c.Assert(len(order), check.Equals, g.Order())
+	pos := make(map[int]int)
+	for i, n := range order {
+		pos[n.ID()] = i
+	}
+	c.Check(len(pos), check.Equals, g.Order())
+	for _, n := range order {
+		var later int
+		for _, e := range n.Edges() {
+			if pos[e.Head().ID()] > pos[n.ID()] || pos[e.Tail().ID()] > pos[n.ID()] {
+				later++
+			}
+		}
+		c.Check(later <= k, check.Equals, true)
+	}
+}
+
+func (s *S) TestKCore(c *check.C) {
+	g := Complete(4)
+	g.AddID(4)
+	g.AddID(5)
+	g.ConnectByID(3, 4)
+	g.ConnectByID(4, 5)
+	g.ConnectByID(0, 1)
+	g.AddID(6)
+
+	k := KCore(g, 3)
+	c.Check(k.Order(), check.Equals, 4)
+	c.Check(k.Size(), check.Equals, 7)
+	for _, e := range k.Edges() {
+		c.Check(g.Edge(e.ID()).Tail().ID(), check.Equals, e.Tail().ID())
+		c.Check(g.Edge(e.ID()).Head().ID(), check.Equals, e.Head().ID())
+	}
+	for _, n := range k.Nodes() {
+		c.Check(n.Degree() >= 3, check.Equals, true)
+	}
+
+	c.Check(KCore(g, 0).Order(), check.Equals, g.Order())
+	c.Check(KCore(g, 1).Order(), check.Equals, 6)
+	c.Check(KCore(g, 5).Order(), check.Equals, 0)
+}
@@ -33,19 +33,26 @@ type EdgeFilter func(Edge) bool
 
 // An edge is an edge in a graph.
 type edge struct {
-	id   int
-	i    int
-	u, v Node
+	id    int
+	i     int
+	u, v  Node
+	w     float64
+	attrs attributes
 }
 
 // NewEdge returns a new Edge.
 func NewEdge() Edge {
-	return &edge{}
+	return &edge{w: 1}
+}
+
+// NewWeightedEdge returns a new Edge with weight w.
+func NewWeightedEdge(w float64) Edge {
+	return &edge{w: w}
 }
 
 // newEdge returns a new edge.
-func newEdge(id, i int, u, v Node) Edge {
-	return &edge{id: id, i: i, u: u, v: v}
+func newEdge(id, i int, u, v Node, w float64) Edge {
+	return &edge{id: id, i: i, u: u, v: v, w: w}
 }
 
 // ID returns the id of the edge.
@@ -84,7 +91,7 @@ func (e *edge) Tail() Node {
 
 // Weight returns the weight of the edge. The default weight is 1.
 func (e *edge) Weight() float64 {
-	return 1
+	return e.w
 }
 
 func (e *edge) reconnect(u, v Node) {
@@ -18,3 +18,17 @@ type S struct{}
 type e struct{ u, v int }
 
 var _ = check.Suite(&S{})
+
+// reweight returns a copy of g with the weight of each edge given by w applied to the IDs of
+// its tail and head nodes.
+func reweight(g *Undirected, w func(u, v int) float64) *Undirected {
+	h := NewUndirected()
+	for _, n := range g.Nodes() {
+		h.AddID(n.ID())
+	}
+	for _, e := range g.Edges() {
+		u, v := e.Tail().ID(), e.Head().ID()
+		h.ConnectWith(h.Node(u), h.Node(v), NewWeightedEdge(w(u, v)))
+	}
+	return h
+}
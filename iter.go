@@ -0,0 +1,122 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package graph
+
+import (
+	"iter"
+)
+
+// The iterators below must not be used while the graph is being modified. They require Go 1.23
+// or later and are not built by earlier releases.
+
+// NodeSeq returns an iterator over the nodes of g, in the order returned by Nodes.
+func (g *Undirected) NodeSeq() iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		for _, n := range g.compNodes {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// EdgeSeq returns an iterator over the edges of g, in the order returned by Edges.
+func (g *Undirected) EdgeSeq() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for _, e := range g.compEdges {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// HopSeq returns an iterator over the hops from n along edges that allow the EdgeFilter function ef
+// to return true, as returned by n.Hops. If ef is nil all edges are included.
+func HopSeq(n Node, ef EdgeFilter) iter.Seq[Hop] {
+	return func(yield func(Hop) bool) {
+		for _, e := range n.Edges() {
+			if ef != nil && !ef(e) {
+				continue
+			}
+			h := Hop{Edge: e, Node: e.Head()}
+			if t := e.Tail(); t.ID() != n.ID() {
+				h.Node = t
+			}
+			if !yield(h) {
+				return
+			}
+		}
+	}
+}
+
+// BFS returns an iterator over the nodes reachable from s in breadth-first order, traversing edges
+// that allow the EdgeFilter function ef to return true, paired with their depth from s. The
+// traversal starts afresh each time the iterator is used and stops when the loop body breaks.
+func (g *Undirected) BFS(s Node, ef EdgeFilter) iter.Seq2[Node, int] {
+	return func(yield func(Node, int) bool) {
+		type item struct {
+			n     Node
+			depth int
+		}
+		visits := mark(s, nil)
+		queue := []item{{s, 0}}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			if !yield(u.n, u.depth) {
+				return
+			}
+			for h := range HopSeq(u.n, ef) {
+				if !marked(h.Node, visits) {
+					visits = mark(h.Node, visits)
+					queue = append(queue, item{h.Node, u.depth + 1})
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the nodes reachable from s in depth-first pre-order, traversing
+// edges that allow the EdgeFilter function ef to return true, paired with their depth in the
+// depth-first search tree. Nodes are visited in the order of DepthFirst.Walk. The traversal
+// starts afresh each time the iterator is used and stops when the loop body breaks.
+func (g *Undirected) DFS(s Node, ef EdgeFilter) iter.Seq2[Node, int] {
+	return func(yield func(Node, int) bool) {
+		visits := mark(s, nil)
+		if !yield(s, 0) {
+			return
+		}
+		// Each element of stack holds the hops remaining
+		// to be explored from a node on the current path.
+		stack := [][]*Hop{s.Hops(ef)}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if len(*top) == 0 {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			v := (*top)[0].Node
+			*top = (*top)[1:]
+			if marked(v, visits) {
+				continue
+			}
+			visits = mark(v, visits)
+			if !yield(v, len(stack)) {
+				return
+			}
+			stack = append(stack, v.Hops(ef))
+		}
+	}
+}
+
+// marked returns whether n is marked in v.
+func marked(n Node, v []bool) bool {
+	id := n.ID()
+	return id >= 0 && id < len(v) && v[id]
+}
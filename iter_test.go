@@ -0,0 +1,78 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package graph
+
+import (
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestNodeEdgeSeq(c *check.C) {
+	g := Petersen()
+	var ns Nodes
+	for n := range g.NodeSeq() {
+		ns = append(ns, n)
+	}
+	c.Check(ns, check.DeepEquals, g.Nodes())
+	var es []Edge
+	for e := range g.EdgeSeq() {
+		es = append(es, e)
+		if len(es) == 3 {
+			break
+		}
+	}
+	c.Check(es, check.DeepEquals, g.Edges()[:3])
+
+	n := g.Node(0)
+	var hops []*Hop
+	for h := range HopSeq(n, func(e Edge) bool { return e.ID() != 0 }) {
+		hops = append(hops, &h)
+	}
+	c.Check(hops, check.DeepEquals, n.Hops(func(e Edge) bool { return e.ID() != 0 }))
+}
+
+func (s *S) TestBFSSeq(c *check.C) {
+	g := BinaryTree(3)
+	var ids []int
+	for n, d := range g.BFS(g.Node(0), nil) {
+		c.Check(d, check.Equals, treeDepth(n.ID()))
+		ids = append(ids, n.ID())
+	}
+	c.Check(ids, check.DeepEquals, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+
+	ids = ids[:0]
+	for n, d := range g.BFS(g.Node(0), nil) {
+		if d > 1 {
+			break
+		}
+		ids = append(ids, n.ID())
+	}
+	c.Check(ids, check.DeepEquals, []int{0, 1, 2})
+}
+
+func (s *S) TestDFSSeq(c *check.C) {
+	g := BinaryTree(2)
+	var ids, depths []int
+	for n, d := range g.DFS(g.Node(0), nil) {
+		ids = append(ids, n.ID())
+		depths = append(depths, d)
+	}
+	c.Check(ids, check.DeepEquals, []int{0, 1, 3, 4, 2, 5, 6})
+	c.Check(depths, check.DeepEquals, []int{0, 1, 2, 2, 1, 2, 2})
+
+	var pre []int
+	NewDepthFirst().Walk(g.Node(0), nil, false, DFSEvents{
+		PreOrder: func(n Node, _ int) { pre = append(pre, n.ID()) },
+	})
+	c.Check(ids, check.DeepEquals, pre)
+
+	ids = ids[:0]
+	for n := range g.DFS(g.Node(0), func(e Edge) bool { return e.Head().ID() != 1 && e.Tail().ID() != 1 }) {
+		ids = append(ids, n.ID())
+	}
+	c.Check(ids, check.DeepEquals, []int{0, 2, 5, 6})
+}
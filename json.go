@@ -0,0 +1,275 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	DirectedJSON     = errors.New("graph: cannot unmarshal directed graph into Undirected")
+	MissingJSONField = errors.New("graph: node-link data missing required field")
+	NonIntegerNodeID = errors.New("graph: node-link node id is not an integer")
+)
+
+// attributes holds the JSON encoded attributes of a graph, node or edge that are retained from
+// node-link data so that they are written again by MarshalJSON.
+type attributes map[string]json.RawMessage
+
+// take decodes the attribute key into dst and removes it from a, returning whether the
+// attribute was present.
+func (a attributes) take(key string, dst interface{}) (bool, error) {
+	v, ok := a[key]
+	if !ok {
+		return false, nil
+	}
+	delete(a, key)
+	return true, json.Unmarshal(v, dst)
+}
+
+// takeID decodes the node ID attribute key into id and removes it from a, returning whether the
+// attribute was present. Node IDs that are not JSON integers result in a NonIntegerNodeID error.
+func (a attributes) takeID(key string, id *int) (bool, error) {
+	ok, err := a.take(key, id)
+	if _, isType := err.(*json.UnmarshalTypeError); isType {
+		err = NonIntegerNodeID
+	}
+	return ok, err
+}
+
+// with returns a copy of a holding the additional attributes in v.
+func (a attributes) with(v map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(a)+len(v))
+	for k, r := range a {
+		m[k] = r
+	}
+	for k, x := range v {
+		m[k] = x
+	}
+	return m
+}
+
+// nodeLink is the node-link representation of a graph used by D3 and the NetworkX
+// node_link_data and node_link_graph functions.
+type nodeLink struct {
+	Directed   bool         `json:"directed"`
+	Multigraph bool         `json:"multigraph"`
+	Graph      attributes   `json:"graph"`
+	Nodes      []attributes `json:"nodes"`
+	Links      []attributes `json:"links"`
+
+	// Edges holds links for documents written by NetworkX
+	// versions that use the "edges" key.
+	Edges []attributes `json:"edges,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The graph is encoded in the node-link
+// format used by D3 and NetworkX, with nodes and links listed in ascending ID order. Each node
+// holds its ID and each link holds the IDs of its tail and head nodes as source and target, and
+// its edge ID and weight. Graph, node and link attributes read by UnmarshalJSON are written
+// unaltered; nodes and edges not created by the graph itself have no attributes. The graph is
+// marked as a multigraph if it has parallel edges.
+func (g *Undirected) MarshalJSON() ([]byte, error) {
+	nl := struct {
+		Directed   bool                     `json:"directed"`
+		Multigraph bool                     `json:"multigraph"`
+		Graph      attributes               `json:"graph"`
+		Nodes      []map[string]interface{} `json:"nodes"`
+		Links      []map[string]interface{} `json:"links"`
+	}{
+		Graph: g.attrs,
+		Nodes: make([]map[string]interface{}, 0, g.Order()),
+		Links: make([]map[string]interface{}, 0, g.Size()),
+	}
+	if nl.Graph == nil {
+		nl.Graph = attributes{}
+	}
+	for _, n := range g.nodes {
+		if n == nil {
+			continue
+		}
+		var a attributes
+		if nn, ok := n.(*node); ok {
+			a = nn.attrs
+		}
+		nl.Nodes = append(nl.Nodes, a.with(map[string]interface{}{"id": n.ID()}))
+	}
+	type pair struct{ u, v int }
+	seen := make(map[pair]bool)
+	for _, e := range g.edges {
+		if e == nil {
+			continue
+		}
+		u, v := e.Tail().ID(), e.Head().ID()
+		if u > v {
+			u, v = v, u
+		}
+		if seen[pair{u, v}] {
+			nl.Multigraph = true
+		}
+		seen[pair{u, v}] = true
+		var a attributes
+		if ee, ok := e.(*edge); ok {
+			a = ee.attrs
+		}
+		nl.Links = append(nl.Links, a.with(map[string]interface{}{
+			"source": e.Tail().ID(),
+			"target": e.Head().ID(),
+			"id":     e.ID(),
+			"weight": e.Weight(),
+		}))
+	}
+
+	return json.Marshal(nl)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, replacing the contents of the receiver
+// with the graph described by the node-link formatted data. Node IDs must be non-negative integers;
+// data using string node IDs, as NetworkX writes for graphs with named nodes, is rejected with a
+// NonIntegerNodeID error and must be relabelled with integers before it is read.
+// Links without an id are given the next available edge ID once all identified links have been
+// added, and links without a weight are given a weight of 1. Other graph, node and link attributes
+// are retained and written by MarshalJSON, but are otherwise not interpreted.
+func (g *Undirected) UnmarshalJSON(data []byte) error {
+	var nl nodeLink
+	err := json.Unmarshal(data, &nl)
+	if err != nil {
+		return err
+	}
+	if nl.Directed {
+		return DirectedJSON
+	}
+	if nl.Links == nil {
+		nl.Links = nl.Edges
+	}
+
+	ng := NewUndirected()
+	if len(nl.Graph) != 0 {
+		ng.attrs = nl.Graph
+	}
+	for _, a := range nl.Nodes {
+		var id int
+		ok, err := a.takeID("id", &id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return MissingJSONField
+		}
+		if id < 0 {
+			return NodeIDOutOfRange
+		}
+		n, err := ng.AddID(id)
+		if err != nil {
+			return err
+		}
+		if nn, ok := n.(*node); ok && len(a) != 0 {
+			nn.attrs = a
+		}
+	}
+
+	var deferred []jsonLink
+	for _, a := range nl.Links {
+		l, err := newJSONLink(a)
+		if err != nil {
+			return err
+		}
+		if l.id == nil {
+			deferred = append(deferred, l)
+			continue
+		}
+		if *l.id < 0 {
+			return EdgeIDOutOfRange
+		}
+		if ng.Edge(*l.id) != nil {
+			return EdgeExists
+		}
+		u, v, err := ng.linkNodes(l)
+		if err != nil {
+			return err
+		}
+		l.add(ng.newEdgeKeepID(*l.id, u, v, l.weight), u, v)
+	}
+	for _, l := range deferred {
+		u, v, err := ng.linkNodes(l)
+		if err != nil {
+			return err
+		}
+		l.add(ng.newEdge(u, v, l.weight), u, v)
+	}
+
+	*g = *ng
+
+	return nil
+}
+
+// jsonLink is a decoded node-link link.
+type jsonLink struct {
+	source, target int
+	id             *int
+	weight         float64
+	attrs          attributes
+}
+
+// newJSONLink returns the link described by a, removing the fields it uses from a.
+func newJSONLink(a attributes) (jsonLink, error) {
+	l := jsonLink{weight: 1}
+	for _, f := range []struct {
+		key string
+		dst *int
+	}{
+		{key: "source", dst: &l.source},
+		{key: "target", dst: &l.target},
+	} {
+		ok, err := a.takeID(f.key, f.dst)
+		if err != nil {
+			return l, err
+		}
+		if !ok {
+			return l, MissingJSONField
+		}
+	}
+	for _, f := range []struct {
+		key string
+		dst interface{}
+	}{
+		{key: "id", dst: &l.id},
+		{key: "weight", dst: &l.weight},
+	} {
+		if _, err := a.take(f.key, f.dst); err != nil {
+			return l, err
+		}
+	}
+	if len(a) != 0 {
+		l.attrs = a
+	}
+	return l, nil
+}
+
+// add adds the edge e described by l to its nodes u and v.
+func (l jsonLink) add(e Edge, u, v Node) {
+	if ee, ok := e.(*edge); ok {
+		ee.attrs = l.attrs
+	}
+	u.add(e)
+	if v != u {
+		v.add(e)
+	}
+}
+
+// linkNodes returns the tail and head nodes of the link l.
+func (g *Undirected) linkNodes(l jsonLink) (u, v Node, err error) {
+	for _, id := range [...]int{l.source, l.target} {
+		ok, err := g.HasNodeID(id)
+		if !ok {
+			if err == nil {
+				err = NodeDoesNotExist
+			}
+			return nil, nil, err
+		}
+	}
+	return g.nodes[l.source], g.nodes[l.target], nil
+}
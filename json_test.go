@@ -0,0 +1,145 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"encoding/json"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestJSONRoundTrip(c *check.C) {
+	g := undirected(c, uv)
+	g.ConnectWith(g.Node(1), g.Node(1), NewWeightedEdge(0.5))
+	g.DeleteByID(deleteNode)
+
+	b, err := json.Marshal(g)
+	c.Assert(err, check.Equals, nil)
+
+	g0 := NewUndirected()
+	err = json.Unmarshal(b, g0)
+	c.Assert(err, check.Equals, nil)
+	c.Check(g0.Order(), check.Equals, g.Order())
+	c.Check(g0.Size(), check.Equals, g.Size())
+	for _, e := range g.Edges() {
+		e0 := g0.Edge(e.ID())
+		c.Assert(e0, check.Not(check.Equals), nil)
+		c.Check(e0.Tail().ID(), check.Equals, e.Tail().ID())
+		c.Check(e0.Head().ID(), check.Equals, e.Head().ID())
+		c.Check(e0.Weight(), check.Equals, e.Weight())
+	}
+	c.Check(g0.Node(deleteNode), check.Equals, nil)
+}
+
+func (s *S) TestJSONNodeLink(c *check.C) {
+	const nx = `{
+	"directed": false,
+	"multigraph": false,
+	"graph": {"name": "test"},
+	"nodes": [{"id": 0, "label": "a"}, {"id": 1}, {"id": 3}],
+	"links": [{"source": 0, "target": 1, "weight": 2.5}, {"source": 1, "target": 3}]
+}`
+	g := NewUndirected()
+	err := json.Unmarshal([]byte(nx), g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(g.Order(), check.Equals, 3)
+	c.Check(g.Size(), check.Equals, 2)
+	c.Check(g.Edge(0).Weight(), check.Equals, 2.5)
+	c.Check(g.Edge(1).Weight(), check.Equals, 1.)
+	c.Check(g.Edge(1).Head().ID(), check.Equals, 3)
+
+	for _, bad := range []string{
+		`{"directed": true, "nodes": [], "links": []}`,
+		`{"nodes": [{"id": -1}], "links": []}`,
+		`{"nodes": [{"id": 0}], "links": [{"source": 0, "target": 1}]}`,
+		`{"nodes": [{"id": 0}], "links": [{"source": 0, "target": 0, "id": 0}, {"source": 0, "target": 0, "id": 0}]}`,
+	} {
+		c.Check(json.Unmarshal([]byte(bad), NewUndirected()), check.Not(check.Equals), nil)
+	}
+}
+
+func (s *S) TestJSONAttributes(c *check.C) {
+	const nx = `{
+	"directed": false,
+	"multigraph": true,
+	"graph": {"name": "test"},
+	"nodes": [{"id": 0, "label": "a"}, {"id": 1, "label": "b", "score": 1.5}, {"id": 2}],
+	"links": [{"source": 0, "target": 1, "key": 0, "kind": "ppi"}, {"source": 1, "target": 2, "weight": 2}]
+}`
+	g := NewUndirected()
+	err := json.Unmarshal([]byte(nx), g)
+	c.Assert(err, check.Equals, nil)
+
+	b, err := json.Marshal(g)
+	c.Assert(err, check.Equals, nil)
+	var got map[string]interface{}
+	c.Assert(json.Unmarshal(b, &got), check.Equals, nil)
+	c.Check(got, check.DeepEquals, map[string]interface{}{
+		"directed":   false,
+		"multigraph": false,
+		"graph":      map[string]interface{}{"name": "test"},
+		"nodes": []interface{}{
+			map[string]interface{}{"id": 0., "label": "a"},
+			map[string]interface{}{"id": 1., "label": "b", "score": 1.5},
+			map[string]interface{}{"id": 2.},
+		},
+		"links": []interface{}{
+			map[string]interface{}{"source": 0., "target": 1., "id": 0., "weight": 1., "key": 0., "kind": "ppi"},
+			map[string]interface{}{"source": 1., "target": 2., "id": 1., "weight": 2.},
+		},
+	})
+
+	// Parallel edges make a multigraph.
+	g.ConnectByID(1, 0)
+	b, err = json.Marshal(g)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(json.Unmarshal(b, &got), check.Equals, nil)
+	c.Check(got["multigraph"], check.Equals, true)
+
+	// Attributes are not retained by new nodes reusing an ID.
+	g.DeleteByID(0)
+	g.AddID(0)
+	b, err = json.Marshal(g)
+	c.Assert(err, check.Equals, nil)
+	got = nil
+	c.Assert(json.Unmarshal(b, &got), check.Equals, nil)
+	c.Check(got["nodes"].([]interface{})[0], check.DeepEquals, map[string]interface{}{"id": 0.})
+
+	for _, bad := range []struct {
+		json string
+		err  error
+	}{
+		{json: `{"directed": true, "nodes": [], "links": []}`, err: DirectedJSON},
+		{json: `{"nodes": [{"label": "a"}], "links": []}`, err: MissingJSONField},
+		{json: `{"nodes": [{"id": 0}], "links": [{"source": 0}]}`, err: MissingJSONField},
+		{json: `{"nodes": [{"id": "a"}], "links": []}`, err: NonIntegerNodeID},
+		{json: `{"nodes": [{"id": 0}], "links": [{"source": 0, "target": "a"}]}`, err: NonIntegerNodeID},
+	} {
+		c.Check(json.Unmarshal([]byte(bad.json), NewUndirected()), check.Equals, bad.err, check.Commentf("%s", bad.json))
+	}
+}
+
+type userNode struct{ Node }
+
+type userEdge struct{ Edge }
+
+func (s *S) TestJSONUserTypes(c *check.C) {
+	g := NewUndirected()
+	g.AddID(0)
+	c.Assert(g.Add(userNode{newNode(1)}), check.Equals, nil)
+	c.Assert(g.ConnectWith(g.Node(0), g.Node(1), userEdge{NewWeightedEdge(2)}), check.Equals, nil)
+
+	b, err := json.Marshal(g)
+	c.Assert(err, check.Equals, nil)
+	var got map[string]interface{}
+	c.Assert(json.Unmarshal(b, &got), check.Equals, nil)
+	c.Check(got["nodes"], check.DeepEquals, []interface{}{
+		map[string]interface{}{"id": 0.},
+		map[string]interface{}{"id": 1.},
+	})
+	c.Check(got["links"], check.DeepEquals, []interface{}{
+		map[string]interface{}{"source": 0., "target": 1., "id": 0., "weight": 2.},
+	})
+}
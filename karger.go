@@ -5,7 +5,10 @@
 package graph
 
 import (
+	"context"
 	"math"
+	"math/rand"
+	"sort"
 	"sync"
 )
 
@@ -17,17 +20,126 @@ import (
 const sqrt2 = 1.4142135623730950488016887242096980785696718753769480
 
 func RandMinCut(g *Undirected, iter int) (c []Edge, w float64) {
+	c, w, _ = RandMinCutWith(g, iter, MinCutOptions{})
+	return c, w
+}
+
+// Progress is a function type used to report the progress of an iterative calculation. It is
+// called after iteration i, counting from 0, with the best weight w found so far. If it returns
+// true, the calculation is stopped.
+type Progress func(i int, w float64) bool
+
+// MinCutOptions holds optional settings for RandMinCutWith.
+type MinCutOptions struct {
+	// Context is checked before each iteration. If it is
+	// done, the best cut found so far is returned with the
+	// error Context.Err(). A nil Context is never done.
+	Context context.Context
+
+	// Progress is called after each iteration if it is not
+	// nil. If it returns true, the best cut found so far is
+	// returned with a nil error.
+	Progress Progress
+
+	// Threads is the number of goroutines used within the
+	// recursion tree of each iteration, as for RandMinCutPar.
+	// If Threads is less than 1, each iteration runs in the
+	// calling goroutine.
+	Threads int
+}
+
+// RandMinCutWith performs RandMinCut or RandMinCutPar according to opts, allowing the
+// calculation to be cancelled and its progress to be followed.
+func RandMinCutWith(g *Undirected, iter int, opts MinCutOptions) (c []Edge, w float64, err error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	ka := newKarger(g)
+	ka.split = opts.Threads
+	return ka.trials(ctx, iter, opts.Threads > 0, opts.Progress)
+}
+
+// trials returns the best cut found by iter independent runs of the Karger–Stein algorithm, each
+// starting from a copy of ka, in parallel if par is true.
+func (ka *karger) trials(ctx context.Context, iter int, par bool, fn Progress) (c []Edge, w float64, err error) {
 	w = math.Inf(1)
 	for i := 0; i < iter; i++ {
-		ka.fastMinCut()
-		if ka.w < w {
-			w = ka.w
-			c = ka.c
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		k := ka.clone()
+		if par {
+			k.split = ka.split
+			k.fastMinCutPar()
+		} else {
+			k.fastMinCut()
+		}
+		if k.w < w {
+			w = k.w
+			c = k.c
+		}
+		if fn != nil && fn(i, w) {
+			break
 		}
 	}
 
-	return c, w
+	return c, w, err
+}
+
+// KargerSteinTrials returns the number of independent runs of the Karger–Stein algorithm on a
+// graph with n nodes needed to find a given minimum cut with probability at least 1-fail. The
+// probability that a single run finds the cut is bounded from below by following the recursion
+// used by RandMinCut, and is Ω(1/log n), so O(log² n) runs are needed for a failure probability
+// of 1/n. KargerSteinTrials panics if fail is not in (0, 1).
+func KargerSteinTrials(n int, fail float64) int {
+	if fail <= 0 || fail >= 1 {
+		panic("graph: failure probability out of range")
+	}
+	if n < 2 {
+		return 0
+	}
+	return trialsFor(kargerSteinSuccess(n), fail)
+}
+
+// kargerSteinSuccess returns a lower bound on the probability that a run of fastMinCut on a
+// graph with n nodes finds a given minimum cut.
+func kargerSteinSuccess(n int) float64 {
+	// Contracting from n to t nodes preserves a given
+	// minimum cut with probability at least t(t-1)/n(n-1).
+	survive := func(n, t int) float64 {
+		return float64(t*(t-1)) / float64(n*(n-1))
+	}
+	if n <= 6 {
+		return survive(n, 2)
+	}
+	t := int(math.Ceil(float64(n)/sqrt2 + 1))
+	q := survive(n, t) * kargerSteinSuccess(t)
+	return 1 - (1-q)*(1-q)
+}
+
+// RandMinCutProb performs RandMinCut with the number of iterations given by KargerSteinTrials
+// for the order of g and the failure probability fail, and also returns the number of distinct
+// minimum weight cuts found across the iterations, cuts being distinguished by the partitions
+// of the nodes of g that they give.
+func RandMinCutProb(g *Undirected, fail float64) (c []Edge, w float64, distinct int) {
+	base := newKarger(g)
+	w = math.Inf(1)
+	found := make(map[string]struct{})
+	for i, n := 0, KargerSteinTrials(g.Order(), fail); i < n; i++ {
+		k := base.clone()
+		k.fastMinCut()
+		switch {
+		case k.w < w:
+			w = k.w
+			c = k.c
+			found = map[string]struct{}{k.partition(): {}}
+		case k.w == w:
+			found[k.partition()] = struct{}{}
+		}
+	}
+
+	return c, w, len(found)
 }
 
 func (ka *karger) fastMinCut() {
@@ -49,23 +161,246 @@ func (ka *karger) fastMinCut() {
 	}
 }
 
-// parallelised within the recursion tree
+// A KCut is a partition of the nodes of a graph into k parts.
+type KCut struct {
+	// Edges holds the edges joining different parts
+	// and Weight their total weight.
+	Edges  []Edge
+	Weight float64
+
+	// Parts holds the nodes of each part in ascending
+	// order of ID, with parts ordered by their lowest
+	// node ID.
+	Parts []Nodes
+}
 
-func RandMinCutPar(g *Undirected, iter, threads int) (c []Edge, w float64) {
-	k := newKarger(g)
-	k.split = threads
-	if k.split == 0 {
-		k.split = -1
+// RandMinKCut returns the least weight partition of g into k parts found in iter iterations of
+// the recursive contraction algorithm of Karger and Stein generalised to k parts. See Karger and
+// Stein doi:10.1145/234533.234534. Each iteration shrinks the graph by a factor of 2^(1/(2(k-1)))
+// at each level of the recursion and considers every partition of the supernodes into k parts
+// once at most 2(k-1) supernodes remain. An iteration finds a given minimum k-cut with probability
+// Ω(1/log n) and takes Õ(n^(2(k-1))) time, so RandMinKCut is only practical for small k. The
+// number of iterations needed to find a minimum k-cut with a given probability is returned by
+// KargerSteinKCutTrials.
+//
+// If g has more than k connected components, whole components are merged to give k parts.
+// RandMinKCut panics if k is less than 2 or greater than the order of g.
+func RandMinKCut(g *Undirected, k, iter int) KCut {
+	if k < 2 || k > g.Order() {
+		panic("graph: number of parts out of range")
 	}
-	w = math.Inf(1)
+
+	base := newKarger(g)
+	var best *karger
 	for i := 0; i < iter; i++ {
-		k.fastMinCutPar()
-		if k.w < w {
-			w = k.w
-			c = k.c
+		ka := base.clone()
+		ka.fastMinKCut(k)
+		if best == nil || ka.w < best.w {
+			best = ka
 		}
 	}
+	if best == nil {
+		return KCut{}
+	}
 
+	c := KCut{Edges: best.c, Weight: best.w, Parts: make([]Nodes, k)}
+	order := make(map[int]int)
+	for id, s := range best.ind {
+		if s.label < 0 {
+			continue
+		}
+		p, ok := order[best.part[s.label]]
+		if !ok {
+			p = len(order)
+			order[best.part[s.label]] = p
+		}
+		c.Parts[p] = append(c.Parts[p], g.Node(id))
+	}
+
+	return c
+}
+
+// KargerSteinKCutTrials returns the number of iterations of RandMinKCut on a graph with n nodes
+// needed to find a given minimum k-cut with probability at least 1-fail. The probability that an
+// iteration finds the cut is bounded from below by following its recursion as for
+// KargerSteinTrials, using the bound of 2(k-1)/i on the probability that contracting a graph
+// with i supernodes destroys a given minimum k-cut. KargerSteinKCutTrials panics if k is less
+// than 2 or fail is not in (0, 1).
+func KargerSteinKCutTrials(n, k int, fail float64) int {
+	if k < 2 {
+		panic("graph: number of parts out of range")
+	}
+	if fail <= 0 || fail >= 1 {
+		panic("graph: failure probability out of range")
+	}
+	if n < k {
+		return 0
+	}
+	return trialsFor(kCutSuccess(n, k), fail)
+}
+
+// trialsFor returns the number of independent trials each succeeding with probability p that
+// are needed for all to fail with probability at most fail.
+func trialsFor(p, fail float64) int {
+	if p >= 1 {
+		return 1
+	}
+	return int(math.Ceil(math.Log(fail) / math.Log1p(-p)))
+}
+
+// kCutSuccess returns a lower bound on the probability that a run of fastMinKCut on a graph
+// with n nodes finds a given minimum k-cut.
+func kCutSuccess(n, k int) float64 {
+	if n <= kCutBase(k) {
+		return 1
+	}
+	t := kCutShrink(n, k)
+	q := kCutSuccess(t, k)
+	for i := t + 1; i <= n; i++ {
+		q *= 1 - float64(2*(k-1))/float64(i)
+	}
+	return 1 - (1-q)*(1-q)
+}
+
+// kCutBase returns the number of supernodes at which fastMinKCut stops contracting and
+// considers every partition into k parts. Below this, the probability that a contraction
+// preserves a given minimum k-cut is not bounded from below.
+func kCutBase(k int) int { return 2 * (k - 1) }
+
+// kCutShrink returns the number of supernodes that fastMinKCut contracts a graph with n
+// supernodes to at each level of its recursion.
+func kCutShrink(n, k int) int {
+	t := int(math.Ceil(float64(n) / math.Pow(2, 1/float64(2*(k-1)))))
+	if t >= n {
+		t = n - 1
+	}
+	if b := kCutBase(k); t < b {
+		t = b
+	}
+	return t
+}
+
+// fastMinKCut partitions the supernodes of ka into k parts by recursive contraction, keeping the
+// better result of two independent contractions at each level of the recursion.
+func (ka *karger) fastMinKCut(k int) {
+	if ka.order <= kCutBase(k) {
+		ka.minKPartition(k)
+		return
+	}
+
+	t := kCutShrink(ka.order, k)
+
+	sub := []*karger{ka, ka.clone()}
+	for _, ks := range sub {
+		ks.contract(t)
+		if ks.order > t {
+			// Only edges of zero weight join the
+			// supernodes, so no further contraction
+			// is possible.
+			ks.minKPartition(k)
+			continue
+		}
+		ks.fastMinKCut(k)
+	}
+
+	if sub[1].w < sub[0].w {
+		*ka = *sub[1]
+	}
+}
+
+// minKPartition sets the partition of the supernodes of ka into k parts to the least weight
+// partition, and sets the cut edges and weight of ka. If ka has more than kCutBase(k) supernodes,
+// they are joined only by edges of zero weight and the supernodes after the first k-1 in order
+// of their lowest node ID are merged into the last part.
+func (ka *karger) minKPartition(k int) {
+	index := make(map[int]int)
+	var labels []int
+	for _, s := range ka.ind {
+		if _, ok := index[s.label]; s.label < 0 || ok {
+			continue
+		}
+		index[s.label] = len(labels)
+		labels = append(labels, s.label)
+	}
+	m := len(labels)
+
+	best := make([]int, m)
+	if m > kCutBase(k) {
+		for i := range best {
+			if i < k-1 {
+				best[i] = i
+			} else {
+				best[i] = k - 1
+			}
+		}
+	} else {
+		// w holds the weights joining each pair of supernodes.
+		w := make([][]float64, m)
+		for i := range w {
+			w[i] = make([]float64, m)
+		}
+		for _, e := range ka.g.Edges() {
+			if ka.loop(e) {
+				continue
+			}
+			i, j := index[ka.ind[e.Head().ID()].label], index[ka.ind[e.Tail().ID()].label]
+			w[i][j] += e.Weight()
+			w[j][i] += e.Weight()
+		}
+
+		// Consider each partition once as a restricted growth
+		// string, with supernode i in part a[i] and no part used
+		// before all lower numbered parts have been used.
+		a := make([]int, m)
+		min := math.Inf(1)
+		var assign func(i, used int, cut float64)
+		assign = func(i, used int, cut float64) {
+			if i == m {
+				if used == k && cut < min {
+					min = cut
+					copy(best, a)
+				}
+				return
+			}
+			if m-i < k-used {
+				return
+			}
+			for p := 0; p <= used && p < k; p++ {
+				a[i] = p
+				d := cut
+				for j := 0; j < i; j++ {
+					if a[j] != p {
+						d += w[i][j]
+					}
+				}
+				if p == used {
+					assign(i+1, used+1, d)
+				} else {
+					assign(i+1, used, d)
+				}
+			}
+		}
+		assign(0, 0, 0)
+	}
+
+	ka.part = make(map[int]int, m)
+	for i, l := range labels {
+		ka.part[l] = best[i]
+	}
+	ka.c, ka.w = []Edge{}, 0
+	for _, e := range ka.g.Edges() {
+		if ka.part[ka.ind[e.Head().ID()].label] == ka.part[ka.ind[e.Tail().ID()].label] {
+			continue
+		}
+		ka.c = append(ka.c, e)
+		ka.w += e.Weight()
+	}
+}
+
+// parallelised within the recursion tree
+
+func RandMinCutPar(g *Undirected, iter, threads int) (c []Edge, w float64) {
+	c, w, _ = RandMinCutWith(g, iter, MinCutOptions{Threads: threads})
 	return c, w
 }
 
@@ -115,6 +450,10 @@ type karger struct {
 	c     []Edge
 	w     float64
 
+	// part holds the part of each supernode label
+	// in a partition into more than two parts.
+	part map[int]int
+
 	count int
 	split int
 }
@@ -222,3 +561,163 @@ func (ka *karger) compact(k int) {
 func (ka *karger) loop(e Edge) bool {
 	return ka.ind[e.Head().ID()].label == ka.ind[e.Tail().ID()].label
 }
+
+// partition returns a key identifying the partition of the nodes of the graph given by the
+// two supernodes of ka.
+func (ka *karger) partition() string {
+	first := -1
+	return ka.partitionBy(func(label int) bool {
+		if first < 0 {
+			first = label
+		}
+		return label == first
+	})
+}
+
+// partitionBy returns a key identifying the partition of the nodes of the graph into the nodes
+// whose supernode labels are in the set described by in and the remaining nodes. The key holds a
+// byte for each node ID, 1 for nodes on the same side as the node with the lowest ID, 0 for nodes
+// on the other side and 2 for IDs not in the graph. in is called in ascending order of node ID.
+func (ka *karger) partitionBy(in func(label int) bool) string {
+	var first, seen bool
+	p := make([]byte, len(ka.ind))
+	for id, s := range ka.ind {
+		if s.label < 0 {
+			p[id] = 2
+			continue
+		}
+		side := in(s.label)
+		if !seen {
+			first, seen = side, true
+		}
+		if side == first {
+			p[id] = 1
+		}
+	}
+	return string(p)
+}
+
+// A Cut is a partition of the nodes of a graph into two sides.
+type Cut struct {
+	// Edges holds the edges joining the sides and
+	// Weight their total weight.
+	Edges  []Edge
+	Weight float64
+
+	// Sides holds the nodes of each side in ascending
+	// order of ID. Sides[0] holds the node with the
+	// lowest ID.
+	Sides [2]Nodes
+}
+
+// RandNearMinCuts returns the distinct cuts of g with weight at most alpha times the least cut
+// weight found, found in iter iterations of random contraction, ordered by weight. See Karger
+// doi:10.1145/234533.234534. In each iteration g is contracted to ⌈2·alpha⌉ supernodes and one
+// partition of the supernodes into two sides is chosen uniformly at random, so that each cut of
+// weight at most alpha times the minimum is found in an iteration with probability
+// Ω(n^(-2·alpha)·2^(-2·alpha)) and each iteration takes O(m) time for a graph with m edges. Cuts
+// are distinguished by their partitions of the nodes of g.
+//
+// If contraction leaves more than ⌈2·alpha⌉ supernodes, as when g has more connected components
+// than that, the supernodes are joined by no edges of non-zero weight and the minimum cut weight
+// is 0. The partition of the supernodes is then chosen in the same way, so the zero weight cuts
+// returned are a sample of the cuts separating the components of g.
+//
+// RandNearMinCuts panics if alpha is less than 1.
+func RandNearMinCuts(g *Undirected, alpha float64, iter int) []Cut {
+	if alpha < 1 {
+		panic("graph: near minimum cut factor less than 1")
+	}
+	r := int(math.Ceil(2 * alpha))
+
+	base := newKarger(g)
+	weights := make(map[string]float64)
+	min := math.Inf(1)
+	in := make(map[int]bool)
+	for i := 0; i < iter; i++ {
+		k := base.clone()
+		k.contract(r)
+
+		var labels []int
+		for id, s := range k.ind {
+			if s.label == id {
+				labels = append(labels, id)
+			}
+		}
+		if len(labels) < 2 {
+			continue
+		}
+
+		// Choose a random side for each supernode but the
+		// last, which is always on the same side to avoid
+		// choosing each partition twice, until the sides
+		// are not empty.
+		for {
+			var any bool
+			for _, l := range labels[:len(labels)-1] {
+				in[l] = rand.Intn(2) == 1
+				any = any || in[l]
+			}
+			if any {
+				break
+			}
+		}
+		in[labels[len(labels)-1]] = false
+
+		key := k.partitionBy(func(label int) bool { return in[label] })
+		if _, ok := weights[key]; ok {
+			continue
+		}
+		w := cutWeight(g, key)
+		weights[key] = w
+		min = math.Min(min, w)
+	}
+
+	var keys []string
+	for key, w := range weights {
+		if w <= alpha*min {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		wi, wj := weights[keys[i]], weights[keys[j]]
+		return wi < wj || (wi == wj && keys[i] < keys[j])
+	})
+	cuts := make([]Cut, len(keys))
+	for i, key := range keys {
+		cuts[i] = cutOf(g, key)
+	}
+
+	return cuts
+}
+
+// cutWeight returns the weight of the cut of g described by the partition key.
+func cutWeight(g *Undirected, key string) float64 {
+	var w float64
+	for _, e := range g.Edges() {
+		if key[e.Head().ID()] != key[e.Tail().ID()] {
+			w += e.Weight()
+		}
+	}
+	return w
+}
+
+// cutOf returns the cut of g described by the partition key.
+func cutOf(g *Undirected, key string) Cut {
+	c := Cut{Edges: []Edge{}}
+	for _, e := range g.Edges() {
+		if key[e.Head().ID()] != key[e.Tail().ID()] {
+			c.Edges = append(c.Edges, e)
+			c.Weight += e.Weight()
+		}
+	}
+	for id := 0; id < len(key); id++ {
+		switch key[id] {
+		case 1:
+			c.Sides[0] = append(c.Sides[0], g.Node(id))
+		case 0:
+			c.Sides[1] = append(c.Sides[1], g.Node(id))
+		}
+	}
+	return c
+}
@@ -5,6 +5,7 @@
 package graph
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"runtime"
@@ -111,6 +112,222 @@ func (s *S) TestKargerFastMinCutPar(c *check.C) {
 	}
 }
 
+func (s *S) TestKargerGenerated(c *check.C) {
+	rand.Seed(0)
+	G := StochasticBlock([]int{10, 10}, [][]float64{{1, 0}, {0, 1}}, rand.New(rand.NewSource(1)))
+	G.ConnectByID(0, 10)
+	lo := int(math.Log(float64(G.Order())))
+	_, mc := RandMinCut(G, lo*lo)
+	c.Check(mc, check.Equals, 1.)
+}
+
+func (s *S) TestKargerContext(c *check.C) {
+	rand.Seed(0)
+	G := createGraph(testG[1])
+	_, mc, err := RandMinCutWith(G, 5, MinCutOptions{Context: context.Background()})
+	c.Check(err, check.IsNil)
+	c.Check(mc, check.Equals, 1.)
+	_, mc, err = RandMinCutWith(G, 5, MinCutOptions{Context: context.Background(), Threads: runtime.GOMAXPROCS(0)})
+	c.Check(err, check.IsNil)
+	c.Check(mc, check.Equals, 1.)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cut, mc, err := RandMinCutWith(G, 5, MinCutOptions{Context: ctx})
+	c.Check(err, check.Equals, context.Canceled)
+	c.Check(cut, check.IsNil)
+	c.Check(math.IsInf(mc, 1), check.Equals, true)
+	_, _, err = RandMinCutWith(G, 5, MinCutOptions{Context: ctx, Threads: 2})
+	c.Check(err, check.Equals, context.Canceled)
+}
+
+func (s *S) TestKargerProgress(c *check.C) {
+	rand.Seed(0)
+	G := createGraph(testG[0])
+	var (
+		n    int
+		last = math.Inf(1)
+	)
+	_, mc, err := RandMinCutWith(G, 20, MinCutOptions{Progress: func(i int, w float64) bool {
+		c.Check(i, check.Equals, n)
+		c.Check(w <= last, check.Equals, true)
+		n++
+		last = w
+		return false
+	}})
+	c.Check(err, check.IsNil)
+	c.Check(n, check.Equals, 20)
+	c.Check(mc, check.Equals, last)
+
+	// Stop as soon as the known minimum is reached.
+	n = 0
+	_, mc, err = RandMinCutWith(G, 1000, MinCutOptions{
+		Progress: func(i int, w float64) bool {
+			n++
+			return w <= cutExpects[0]
+		},
+		Threads: runtime.GOMAXPROCS(0),
+	})
+	c.Check(err, check.IsNil)
+	c.Check(mc, check.Equals, cutExpects[0])
+	c.Check(n < 1000, check.Equals, true)
+}
+
+func (s *S) TestKargerSteinTrials(c *check.C) {
+	c.Check(KargerSteinTrials(1, 0.1), check.Equals, 0)
+	c.Check(KargerSteinTrials(2, 0.1), check.Equals, 1)
+	for _, n := range []int{10, 100, 1000, 10000} {
+		t := KargerSteinTrials(n, 1/float64(n))
+		c.Check(t > KargerSteinTrials(n, 0.5), check.Equals, true)
+		lg := math.Log2(float64(n))
+		c.Check(float64(t) <= 2*lg*lg, check.Equals, true, check.Commentf("n=%d t=%d", n, t))
+	}
+	c.Check(func() { KargerSteinTrials(10, 0) }, check.Panics, "graph: failure probability out of range")
+	c.Check(func() { KargerSteinTrials(10, 1) }, check.Panics, "graph: failure probability out of range")
+}
+
+func (s *S) TestRandMinCutProb(c *check.C) {
+	rand.Seed(0)
+	G := createGraph(testG[0])
+	_, mc, n := RandMinCutProb(G, 1e-3)
+	c.Check(mc, check.Equals, cutExpects[0])
+	c.Check(n >= 1, check.Equals, true)
+
+	// A barbell has a single minimum cut at its bridge.
+	cut, mc, n := RandMinCutProb(Barbell(5, 0), 1e-3)
+	c.Check(mc, check.Equals, 1.)
+	c.Check(n, check.Equals, 1)
+	c.Check(len(cut), check.Equals, 1)
+
+	// A cycle of n nodes has n(n-1)/2 minimum cuts.
+	_, mc, n = RandMinCutProb(Cycle(5), 1e-6)
+	c.Check(mc, check.Equals, 2.)
+	c.Check(n > 1 && n <= 10, check.Equals, true)
+}
+
+func (s *S) TestRandNearMinCuts(c *check.C) {
+	rand.Seed(0)
+
+	// A cycle of n nodes has n(n-1)/2 minimum cuts and
+	// no other cuts of weight less than 4.
+	cuts := RandNearMinCuts(Cycle(6), 1.5, 500)
+	c.Check(len(cuts), check.Equals, 15)
+	for _, cut := range cuts {
+		c.Check(cut.Weight, check.Equals, 2.)
+		c.Check(len(cut.Edges), check.Equals, 2)
+		c.Check(cut.Sides[0][0].ID(), check.Equals, 0)
+		c.Check(len(cut.Sides[0])+len(cut.Sides[1]), check.Equals, 6)
+	}
+
+	// A path of 5 nodes has 4 cuts of weight 1 and 6 of
+	// weight 2.
+	cuts = RandNearMinCuts(Path(5), 2, 200)
+	c.Assert(len(cuts), check.Equals, 10)
+	for i, cut := range cuts {
+		w := 1.
+		if i >= 4 {
+			w = 2
+		}
+		c.Check(cut.Weight, check.Equals, w)
+		for _, e := range cut.Edges {
+			u, v := e.Nodes()
+			var in int
+			for _, n := range cut.Sides[0] {
+				if n == u || n == v {
+					in++
+				}
+			}
+			c.Check(in, check.Equals, 1)
+		}
+	}
+	c.Check(nodeIDs(cuts[0].Sides[0]), check.DeepEquals, []int{0})
+	c.Check(nodeIDs(cuts[0].Sides[1]), check.DeepEquals, []int{1, 2, 3, 4})
+
+	// Many connected components give zero weight cuts
+	// separating the components, found one per iteration.
+	g := Path(4)
+	for i := 4; i < 4+100; i++ {
+		g.AddID(i)
+	}
+	cuts = RandNearMinCuts(g, 1, 5)
+	c.Check(len(cuts) > 0 && len(cuts) <= 5, check.Equals, true)
+	for _, cut := range cuts {
+		c.Check(cut.Weight, check.Equals, 0.)
+		c.Check(cut.Edges, check.DeepEquals, []Edge{})
+		c.Check(len(cut.Sides[0])+len(cut.Sides[1]), check.Equals, g.Order())
+	}
+
+	// Large factors do not enumerate partitions.
+	cuts = RandNearMinCuts(Cycle(40), 40, 5)
+	c.Check(len(cuts) > 0 && len(cuts) <= 5, check.Equals, true)
+
+	c.Check(func() { RandNearMinCuts(Path(5), 0.5, 1) }, check.Panics, "graph: near minimum cut factor less than 1")
+}
+
+func (s *S) TestKargerSteinKCutTrials(c *check.C) {
+	c.Check(KargerSteinKCutTrials(2, 3, 0.1), check.Equals, 0)
+	c.Check(KargerSteinKCutTrials(4, 3, 0.1), check.Equals, 1)
+	for _, n := range []int{10, 100, 1000} {
+		for k := 2; k <= 4; k++ {
+			t := KargerSteinKCutTrials(n, k, 1/float64(n))
+			c.Check(t >= KargerSteinKCutTrials(n, k, 0.5), check.Equals, true)
+			lg := math.Log2(float64(n))
+			c.Check(float64(t) <= 4*lg*lg, check.Equals, true, check.Commentf("n=%d k=%d t=%d", n, k, t))
+		}
+	}
+	c.Check(func() { KargerSteinKCutTrials(10, 1, 0.1) }, check.Panics, "graph: number of parts out of range")
+	c.Check(func() { KargerSteinKCutTrials(10, 3, 1) }, check.Panics, "graph: failure probability out of range")
+}
+
+func (s *S) TestRandMinKCut(c *check.C) {
+	rand.Seed(0)
+	g := ringOfCliques(4, 4)
+	kc := RandMinKCut(g, 4, 10)
+	c.Check(kc.Weight, check.Equals, 4.)
+	c.Check(len(kc.Edges), check.Equals, 4)
+	c.Assert(len(kc.Parts), check.Equals, 4)
+	for i, p := range kc.Parts {
+		c.Check(nodeIDs(p), check.DeepEquals, []int{4 * i, 4*i + 1, 4*i + 2, 4*i + 3})
+	}
+
+	// Iterations given by KargerSteinKCutTrials find the minimum.
+	g = ringOfCliques(3, 5)
+	kc = RandMinKCut(g, 3, KargerSteinKCutTrials(g.Order(), 3, 1e-3))
+	c.Check(kc.Weight, check.Equals, 3.)
+	c.Check(len(kc.Parts), check.Equals, 3)
+
+	// The minimum 2-cut agrees with RandMinCut.
+	G := createGraph(testG[0])
+	kc = RandMinKCut(G, 2, 5)
+	c.Check(kc.Weight, check.Equals, cutExpects[0])
+	c.Check(len(kc.Parts), check.Equals, 2)
+
+	// Disconnected components are merged into the last part.
+	d := Path(2)
+	for i := 2; i < 8; i += 2 {
+		d.AddID(i)
+		d.AddID(i + 1)
+		d.ConnectByID(i, i+1)
+	}
+	kc = RandMinKCut(d, 2, 1)
+	c.Check(kc.Weight, check.Equals, 0.)
+	c.Check(kc.Edges, check.DeepEquals, []Edge{})
+	c.Assert(len(kc.Parts), check.Equals, 2)
+	c.Check(nodeIDs(kc.Parts[0]), check.DeepEquals, []int{0, 1})
+	c.Check(nodeIDs(kc.Parts[1]), check.DeepEquals, []int{2, 3, 4, 5, 6, 7})
+
+	// Edges of zero weight within a merged part are not cut.
+	d.ConnectWith(d.Node(4), d.Node(7), NewWeightedEdge(0))
+	d.ConnectWith(d.Node(1), d.Node(2), NewWeightedEdge(0))
+	kc = RandMinKCut(d, 2, 1)
+	c.Check(kc.Weight, check.Equals, 0.)
+	c.Assert(len(kc.Edges), check.Equals, 1)
+	c.Check(kc.Edges[0].ID(), check.Equals, d.Size()-1)
+
+	c.Check(func() { RandMinKCut(d, 1, 1) }, check.Panics, "graph: number of parts out of range")
+	c.Check(func() { RandMinKCut(d, 9, 1) }, check.Panics, "graph: number of parts out of range")
+}
+
 func BenchmarkFastKarger(b *testing.B) {
 	G := createGraph(testG[0])
 	lo := int(math.Log(float64(G.Order())))
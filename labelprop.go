@@ -0,0 +1,160 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+)
+
+// LabelPropagation returns the communities of g found by asynchronous label propagation as a
+// slice of slices of nodes. See Raghavan et al. doi:10.1103/PhysRevE.76.036106.
+//
+// In each iteration the nodes are visited in an order chosen using rnd, and each node adopts
+// the label with the greatest total weight among its neighbors, keeping its current label if
+// that is one of the best and otherwise breaking ties using rnd. If weighted is false, each edge
+// has weight 1, otherwise edge weights must be finite and non-negative and the error BadWeight
+// is returned if they are not. Self-loops are ignored. Iteration stops when no label changes, or after maxIter
+// iterations, in which case the communities of the last iteration are returned with the error
+// NotConverged.
+//
+// If seeds is nil, each node starts with a unique label. Otherwise the propagation is
+// semi-supervised: the nodes with IDs in seeds are given the mapped labels, which they keep
+// throughout, and all other nodes start unlabelled. Nodes that are never reached by a label
+// are returned as singleton communities.
+//
+// Communities are ordered by their lowest node ID and hold nodes in ascending order of ID.
+func LabelPropagation(g *Undirected, weighted bool, seeds map[int]int, maxIter int, rnd *rand.Rand) ([]Nodes, error) {
+	ix := NewNodeIndex(g)
+	n := ix.Len()
+
+	// Labels are held as indices into the label set, so
+	// that seed labels and unique labels cannot collide.
+	label := make([]int, n)
+	fixed := make([]bool, n)
+	var nLabels int
+	if seeds == nil {
+		for i := range label {
+			label[i] = i
+		}
+		nLabels = n
+	} else {
+		for i := range label {
+			label[i] = -1
+		}
+		index := make(map[int]int)
+		for id, l := range seeds {
+			i := ix.Row(id)
+			if i < 0 {
+				return nil, NodeDoesNotExist
+			}
+			li, ok := index[l]
+			if !ok {
+				li = len(index)
+				index[l] = li
+			}
+			label[i] = li
+			fixed[i] = true
+		}
+		nLabels = len(index)
+	}
+
+	adj := make([][]wHop, n)
+	for _, e := range g.Edges() {
+		u, v := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		if u == v {
+			continue
+		}
+		w := 1.
+		if weighted {
+			w = e.Weight()
+			if !(w >= 0) || math.IsInf(w, 1) {
+				return nil, BadWeight
+			}
+		}
+		adj[u] = append(adj[u], wHop{v, w})
+		adj[v] = append(adj[v], wHop{u, w})
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	weight := make([]float64, nLabels)
+	inSeen := make([]bool, nLabels)
+	var seen, best []int
+	err := NotConverged
+	for it := 0; it < maxIter; it++ {
+		rnd.Shuffle(n, func(i, j int) { order[i], order[j] = order[j], order[i] })
+		var changed bool
+		for _, v := range order {
+			if fixed[v] {
+				continue
+			}
+			seen = seen[:0]
+			for _, h := range adj[v] {
+				l := label[h.to]
+				if l < 0 {
+					continue
+				}
+				if !inSeen[l] {
+					inSeen[l] = true
+					seen = append(seen, l)
+				}
+				weight[l] += h.w
+			}
+			if len(seen) == 0 {
+				continue
+			}
+
+			best = best[:0]
+			var max float64
+			keep := false
+			for _, l := range seen {
+				switch w := weight[l]; {
+				case w > max:
+					max = w
+					best = append(best[:0], l)
+					keep = l == label[v]
+				case w == max:
+					best = append(best, l)
+					keep = keep || l == label[v]
+				}
+			}
+			for _, l := range seen {
+				weight[l] = 0
+				inSeen[l] = false
+			}
+			if keep {
+				continue
+			}
+			label[v] = best[rnd.Intn(len(best))]
+			changed = true
+		}
+		if !changed {
+			err = nil
+			break
+		}
+	}
+
+	byLabel := make(map[int]int)
+	var communities []Nodes
+	for i, l := range label {
+		n := g.Node(ix.ID(i))
+		if l < 0 {
+			communities = append(communities, Nodes{n})
+			continue
+		}
+		c, ok := byLabel[l]
+		if !ok {
+			c = len(communities)
+			byLabel[l] = c
+			communities = append(communities, nil)
+		}
+		communities[c] = append(communities[c], n)
+	}
+
+	return communities, err
+}
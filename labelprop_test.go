@@ -0,0 +1,87 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestLabelPropagation(c *check.C) {
+	g := ringOfCliques(6, 5)
+	cl, err := LabelPropagation(g, false, nil, 100, rand.New(rand.NewSource(1)))
+	c.Assert(err, check.Equals, nil)
+	c.Check(len(cl), check.Equals, 6)
+	for i, ns := range cl {
+		c.Check(len(ns), check.Equals, 5)
+		for _, n := range ns {
+			c.Check(n.ID()/5, check.Equals, i)
+		}
+	}
+
+	// Isolated nodes keep their own label.
+	g = Complete(3)
+	g.AddID(3)
+	cl, err = LabelPropagation(g, false, nil, 100, rand.New(rand.NewSource(1)))
+	c.Assert(err, check.Equals, nil)
+	c.Check(len(cl), check.Equals, 2)
+}
+
+func (s *S) TestLabelPropagationSeeded(c *check.C) {
+	// Seeds at each end of a path split it at the middle, with
+	// heavy edges pulling the unseeded middle node to one side.
+	g := reweight(Path(5), func(u, v int) float64 {
+		return [...]float64{1, 1, 10, 20}[u]
+	})
+	g.AddID(5)
+	seeds := map[int]int{0: 7, 4: 9}
+	cl, err := LabelPropagation(g, true, seeds, 100, rand.New(rand.NewSource(1)))
+	c.Assert(err, check.Equals, nil)
+	c.Assert(len(cl), check.Equals, 3)
+	ids := func(ns Nodes) []int {
+		var id []int
+		for _, n := range ns {
+			id = append(id, n.ID())
+		}
+		return id
+	}
+	c.Check(ids(cl[0]), check.DeepEquals, []int{0, 1})
+	c.Check(ids(cl[1]), check.DeepEquals, []int{2, 3, 4})
+	c.Check(ids(cl[2]), check.DeepEquals, []int{5})
+
+	_, err = LabelPropagation(g, true, map[int]int{10: 1}, 100, rand.New(rand.NewSource(1)))
+	c.Check(err, check.Equals, NodeDoesNotExist)
+}
+
+func (s *S) TestLabelPropagationWeights(c *check.C) {
+	// Node 0 is tied between label 1, reached by a zero and a unit
+	// weight edge, and label 2, reached by a unit weight edge.
+	g := NewUndirected()
+	for id := 0; id < 4; id++ {
+		g.AddID(id)
+	}
+	g.ConnectWith(g.Node(0), g.Node(1), NewWeightedEdge(0))
+	g.ConnectWith(g.Node(0), g.Node(2), NewWeightedEdge(1))
+	g.ConnectWith(g.Node(0), g.Node(3), NewWeightedEdge(1))
+	seeds := map[int]int{1: 1, 2: 1, 3: 2}
+	const runs = 1000
+	var withOne int
+	for i := 0; i < runs; i++ {
+		cl, err := LabelPropagation(g, true, seeds, 10, rand.New(rand.NewSource(int64(i))))
+		c.Assert(err, check.Equals, nil)
+		if len(cl[0]) == 3 {
+			withOne++
+		}
+	}
+	c.Check(withOne > runs/2-80 && withOne < runs/2+80, check.Equals, true, check.Commentf("%d of %d", withOne, runs))
+
+	for _, w := range []float64{-1, math.NaN()} {
+		g = reweight(Path(3), func(u, v int) float64 { return w })
+		_, err := LabelPropagation(g, true, nil, 10, rand.New(rand.NewSource(1)))
+		c.Check(err, check.Equals, BadWeight)
+	}
+}
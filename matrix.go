@@ -0,0 +1,296 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	NotSquare    = errors.New("graph: matrix is not square")
+	NotSymmetric = errors.New("graph: matrix is not symmetric")
+)
+
+// A NodeIndex is a stable mapping between node IDs and matrix rows. Rows are assigned to
+// nodes in ascending order of node ID.
+type NodeIndex struct {
+	ids  []int
+	rows []int
+}
+
+// NewNodeIndex returns a NodeIndex for the nodes of g.
+func NewNodeIndex(g *Undirected) *NodeIndex {
+	ix := &NodeIndex{rows: make([]int, len(g.nodes))}
+	for id, n := range g.nodes {
+		if n == nil {
+			ix.rows[id] = -1
+			continue
+		}
+		ix.rows[id] = len(ix.ids)
+		ix.ids = append(ix.ids, id)
+	}
+
+	return ix
+}
+
+// Len returns the number of indexed nodes.
+func (ix *NodeIndex) Len() int { return len(ix.ids) }
+
+// Row returns the row assigned to the node with ID id, or -1 if the node is not indexed.
+func (ix *NodeIndex) Row(id int) int {
+	if id < 0 || id >= len(ix.rows) {
+		return -1
+	}
+	return ix.rows[id]
+}
+
+// ID returns the ID of the node assigned to row i.
+func (ix *NodeIndex) ID(i int) int { return ix.ids[i] }
+
+// A Matrix is a two dimensional matrix of float64 values.
+type Matrix interface {
+	Dims() (r, c int)
+	At(i, j int) float64
+}
+
+var (
+	_ Matrix = (*Dense)(nil)
+	_ Matrix = (*CSR)(nil)
+)
+
+// A Dense is a dense row-major matrix.
+type Dense struct {
+	Rows, Cols int
+	Data       []float64
+}
+
+// NewDense returns a new zeroed r×c Dense matrix.
+func NewDense(r, c int) *Dense {
+	return &Dense{Rows: r, Cols: c, Data: make([]float64, r*c)}
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *Dense) Dims() (r, c int) { return m.Rows, m.Cols }
+
+// At returns the value of element (i, j).
+func (m *Dense) At(i, j int) float64 { return m.Data[i*m.Cols+j] }
+
+// Set sets the value of element (i, j) to v.
+func (m *Dense) Set(i, j int, v float64) { m.Data[i*m.Cols+j] = v }
+
+func (m *Dense) add(i, j int, v float64) { m.Data[i*m.Cols+j] += v }
+
+// A COO is a sparse matrix in coordinate format. The value of element (I[k], J[k]) is V[k].
+// Repeated coordinates are summed.
+type COO struct {
+	Rows, Cols int
+	I, J       []int
+	V          []float64
+}
+
+func (m *COO) add(i, j int, v float64) {
+	m.I = append(m.I, i)
+	m.J = append(m.J, j)
+	m.V = append(m.V, v)
+}
+
+// CSR returns the compressed sparse row form of the matrix, summing repeated coordinates.
+func (m *COO) CSR() *CSR {
+	c := &CSR{Rows: m.Rows, Cols: m.Cols, Indptr: make([]int, m.Rows+1)}
+	order := make([]int, len(m.V))
+	for k := range order {
+		order[k] = k
+	}
+	sort.Slice(order, func(a, b int) bool {
+		ka, kb := order[a], order[b]
+		if m.I[ka] != m.I[kb] {
+			return m.I[ka] < m.I[kb]
+		}
+		return m.J[ka] < m.J[kb]
+	})
+	last := -1
+	for _, k := range order {
+		i, j := m.I[k], m.J[k]
+		if n := len(c.Ind); i == last && c.Ind[n-1] == j {
+			c.V[n-1] += m.V[k]
+			continue
+		}
+		c.Ind = append(c.Ind, j)
+		c.V = append(c.V, m.V[k])
+		c.Indptr[i+1]++
+		last = i
+	}
+	for i := 0; i < m.Rows; i++ {
+		c.Indptr[i+1] += c.Indptr[i]
+	}
+
+	return c
+}
+
+// A CSR is a sparse matrix in compressed sparse row format. The column indices and values of
+// row i are held in Ind[Indptr[i]:Indptr[i+1]] and V[Indptr[i]:Indptr[i+1]], with column
+// indices in ascending order.
+type CSR struct {
+	Rows, Cols int
+	Indptr     []int
+	Ind        []int
+	V          []float64
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *CSR) Dims() (r, c int) { return m.Rows, m.Cols }
+
+// At returns the value of element (i, j).
+func (m *CSR) At(i, j int) float64 {
+	lo, hi := m.Indptr[i], m.Indptr[i+1]
+	k := lo + sort.SearchInts(m.Ind[lo:hi], j)
+	if k < hi && m.Ind[k] == j {
+		return m.V[k]
+	}
+	return 0
+}
+
+// AdjacencyMatrix returns the weighted adjacency matrix of g and the mapping between node IDs and
+// matrix rows. Weights of parallel edges are summed. A self-loop contributes twice its weight to
+// the diagonal, so that row sums are equal to the weighted degree of the node.
+func AdjacencyMatrix(g *Undirected) (*Dense, *NodeIndex) {
+	ix := NewNodeIndex(g)
+	a := NewDense(ix.Len(), ix.Len())
+	for _, e := range g.Edges() {
+		i, j := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		w := e.Weight()
+		a.add(i, j, w)
+		a.add(j, i, w)
+	}
+
+	return a, ix
+}
+
+// AdjacencyCOO returns the weighted adjacency matrix of g in coordinate format and the mapping
+// between node IDs and matrix rows. Each edge contributes an entry at both of its coordinates,
+// so parallel edges and self-loops are summed as described for AdjacencyMatrix.
+func AdjacencyCOO(g *Undirected) (*COO, *NodeIndex) {
+	ix := NewNodeIndex(g)
+	a := &COO{Rows: ix.Len(), Cols: ix.Len()}
+	for _, e := range g.Edges() {
+		i, j := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		w := e.Weight()
+		a.add(i, j, w)
+		a.add(j, i, w)
+	}
+
+	return a, ix
+}
+
+// DegreeMatrix returns the diagonal weighted degree matrix of g and the mapping between node
+// IDs and matrix rows. Self-loops are counted at both ends, as for Node.Degree.
+func DegreeMatrix(g *Undirected) (*Dense, *NodeIndex) {
+	ix := NewNodeIndex(g)
+	d := NewDense(ix.Len(), ix.Len())
+	for i, w := range weightedDegrees(g, ix) {
+		d.Set(i, i, w)
+	}
+
+	return d, ix
+}
+
+// LaplacianMatrix returns the graph Laplacian, L = D - A, of g and the mapping between node IDs
+// and matrix rows.
+func LaplacianMatrix(g *Undirected) (*Dense, *NodeIndex) {
+	l, ix := AdjacencyMatrix(g)
+	for i := range l.Data {
+		l.Data[i] = -l.Data[i]
+	}
+	for i, w := range weightedDegrees(g, ix) {
+		l.add(i, i, w)
+	}
+
+	return l, ix
+}
+
+// LaplacianCOO returns the graph Laplacian of g in coordinate format and the mapping between node
+// IDs and matrix rows.
+func LaplacianCOO(g *Undirected) (*COO, *NodeIndex) {
+	l, ix := AdjacencyCOO(g)
+	for k := range l.V {
+		l.V[k] = -l.V[k]
+	}
+	for i, w := range weightedDegrees(g, ix) {
+		l.add(i, i, w)
+	}
+
+	return l, ix
+}
+
+// weightedDegrees returns the sum of incident edge weights for each row of ix.
+func weightedDegrees(g *Undirected, ix *NodeIndex) []float64 {
+	d := make([]float64, ix.Len())
+	for _, e := range g.Edges() {
+		w := e.Weight()
+		d[ix.Row(e.Tail().ID())] += w
+		d[ix.Row(e.Head().ID())] += w
+	}
+
+	return d
+}
+
+// FromAdjacency returns a new Undirected graph described by the symmetric adjacency matrix m.
+// Node IDs are the row indices of m. Each non-zero element above the diagonal is represented by
+// an edge with the element's value as its weight and each non-zero diagonal element by a
+// self-loop with half the element's value as its weight, the inverse of AdjacencyMatrix.
+func FromAdjacency(m Matrix) (*Undirected, error) {
+	r, c := m.Dims()
+	if r != c {
+		return nil, NotSquare
+	}
+
+	g := NewUndirected()
+	for i := 0; i < r; i++ {
+		g.AddID(i)
+	}
+
+	// Each nonzero element is checked against its transpose,
+	// but only the upper triangle is used to make edges.
+	connect := func(i, j int, v float64) error {
+		if v == 0 {
+			return nil
+		}
+		if m.At(j, i) != v {
+			return NotSymmetric
+		}
+		if j < i {
+			return nil
+		}
+		if i == j {
+			v /= 2
+		}
+		g.connectIDs(i, j, v)
+		return nil
+	}
+
+	if s, ok := m.(*CSR); ok {
+		for i := 0; i < r; i++ {
+			for k := s.Indptr[i]; k < s.Indptr[i+1]; k++ {
+				err := connect(i, s.Ind[k], s.V[k])
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return g, nil
+	}
+
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			err := connect(i, j, m.At(i, j))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,79 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestAdjacencyMatrix(c *check.C) {
+	g := undirected(c, uv)
+	g.ConnectWith(g.Node(2), g.Node(2), NewWeightedEdge(0.5))
+	g.ConnectWith(g.Node(1), g.Node(4), NewWeightedEdge(2))
+
+	a, ix := AdjacencyMatrix(g)
+	c.Check(ix.Len(), check.Equals, g.Order())
+	for i := 0; i < ix.Len(); i++ {
+		c.Check(ix.Row(ix.ID(i)), check.Equals, i)
+		if i > 0 {
+			c.Check(ix.ID(i) > ix.ID(i-1), check.Equals, true)
+		}
+	}
+	c.Check(ix.Row(0), check.Equals, -1)
+	c.Check(a.At(ix.Row(1), ix.Row(4)), check.Equals, 3.)
+	c.Check(a.At(ix.Row(4), ix.Row(1)), check.Equals, 3.)
+	c.Check(a.At(ix.Row(2), ix.Row(2)), check.Equals, 1.)
+
+	sp, _ := AdjacencyCOO(g)
+	csr := sp.CSR()
+	r, cols := a.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < cols; j++ {
+			c.Check(csr.At(i, j), check.Equals, a.At(i, j))
+		}
+	}
+
+	l, _ := LaplacianMatrix(g)
+	lc, _ := LaplacianCOO(g)
+	lcsr := lc.CSR()
+	d, _ := DegreeMatrix(g)
+	for i := 0; i < r; i++ {
+		var sum float64
+		for j := 0; j < cols; j++ {
+			sum += l.At(i, j)
+			c.Check(lcsr.At(i, j), check.Equals, l.At(i, j))
+		}
+		c.Check(sum, check.Equals, 0.)
+		c.Check(d.At(i, i), check.Equals, l.At(i, i)+a.At(i, i))
+	}
+}
+
+func (s *S) TestFromAdjacency(c *check.C) {
+	g := undirected(c, uv)
+	g.ConnectWith(g.Node(2), g.Node(2), NewWeightedEdge(0.5))
+	a, _ := AdjacencyMatrix(g)
+	sp, _ := AdjacencyCOO(g)
+	for _, m := range []Matrix{a, sp.CSR()} {
+		g0, err := FromAdjacency(m)
+		c.Assert(err, check.Equals, nil)
+		c.Check(g0.Order(), check.Equals, g.Order())
+		c.Check(g0.Size(), check.Equals, g.Size())
+		a0, _ := AdjacencyMatrix(g0)
+		c.Check(a0, check.DeepEquals, a)
+	}
+
+	asym := NewDense(2, 2)
+	asym.Set(0, 1, 1)
+	_, err := FromAdjacency(asym)
+	c.Check(err, check.Equals, NotSymmetric)
+	lower := NewDense(2, 2)
+	lower.Set(1, 0, 1)
+	_, err = FromAdjacency(lower)
+	c.Check(err, check.Equals, NotSymmetric)
+	_, err = FromAdjacency((&COO{Rows: 2, Cols: 2, I: []int{1}, J: []int{0}, V: []float64{1}}).CSR())
+	c.Check(err, check.Equals, NotSymmetric)
+	_, err = FromAdjacency(NewDense(2, 3))
+	c.Check(err, check.Equals, NotSquare)
+}
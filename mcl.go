@@ -0,0 +1,320 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// A MarkovCluster performs Markov clustering (MCL) of the nodes of a graph. See van Dongen,
+// Graph Clustering by Flow Simulation, PhD thesis, University of Utrecht, 2000.
+type MarkovCluster struct {
+	// Expansion is the power to which the flow matrix is
+	// raised in each iteration.
+	Expansion int
+
+	// Inflation is the power to which each element of the
+	// flow matrix is raised before renormalization in each
+	// iteration. Greater values give finer clusterings.
+	Inflation float64
+
+	// Prune is the value below which flow matrix elements
+	// are set to zero after each expansion.
+	Prune float64
+
+	// SelfLoop is the weight added to the diagonal of the
+	// adjacency matrix for each node before clustering. If
+	// SelfLoop is MaxWeightLoop, each node is given the
+	// greatest weight of its incident edges, or 1 if it has
+	// none. Existing self-loops in the graph contribute their
+	// weight once.
+	SelfLoop float64
+
+	// Weighted specifies whether edge weights are used.
+	// Otherwise each edge has weight 1.
+	Weighted bool
+
+	// Tolerance and MaxIter control convergence. Iteration
+	// stops when no element of the flow matrix changes by
+	// more than Tolerance.
+	Tolerance float64
+	MaxIter   int
+}
+
+// MaxWeightLoop is a MarkovCluster SelfLoop value that gives each node a self-loop weighted
+// with the greatest weight of its incident edges, as done by the mcl program.
+const MaxWeightLoop = -1
+
+// NewMarkovCluster returns a MarkovCluster with an expansion of 2, inflation of 2, pruning
+// threshold of 1e-5, MaxWeightLoop self-loops, tolerance of 1e-9 and a limit of 100 iterations
+// that uses edge weights.
+func NewMarkovCluster() *MarkovCluster {
+	return &MarkovCluster{
+		Expansion: 2,
+		Inflation: 2,
+		Prune:     1e-5,
+		SelfLoop:  MaxWeightLoop,
+		Weighted:  true,
+		Tolerance: 1e-9,
+		MaxIter:   100,
+	}
+}
+
+// Cluster returns the clusters of g found by MCL as a slice of slices of nodes. Nodes attracted
+// to more than one attractor have their clusters merged, so the clusters form a partition of
+// the nodes of g. Clusters are ordered by their lowest node ID and hold nodes in ascending order
+// of ID. If the flow matrix does not converge within MaxIter iterations, the clusters of the
+// last iteration are returned with the error NotConverged.
+func (m *MarkovCluster) Cluster(g *Undirected) ([]Nodes, error) {
+	return m.ClusterContext(context.Background(), g)
+}
+
+// ClusterContext performs Cluster, checking whether ctx is done before each iteration. If ctx is
+// done, the clusters of the last completed iteration are returned with the error ctx.Err().
+func (m *MarkovCluster) ClusterContext(ctx context.Context, g *Undirected) ([]Nodes, error) {
+	ix := NewNodeIndex(g)
+	n := ix.Len()
+
+	// The flow matrix is held as sparse columns.
+	f := make(sparse, n)
+	loop := make([]float64, n)
+	for _, e := range g.Edges() {
+		w := 1.
+		if m.Weighted {
+			w = e.Weight()
+		}
+		u, v := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		f[u] = append(f[u], entry{v, w})
+		if u != v {
+			f[v] = append(f[v], entry{u, w})
+		}
+		loop[u] = math.Max(loop[u], w)
+		loop[v] = math.Max(loop[v], w)
+	}
+	for j := range f {
+		if m.SelfLoop != MaxWeightLoop {
+			loop[j] = m.SelfLoop
+		} else if loop[j] == 0 {
+			loop[j] = 1
+		}
+		if loop[j] != 0 {
+			f[j] = append(f[j], entry{j, loop[j]})
+		}
+		f[j] = f[j].merge()
+		f[j].normalize()
+	}
+
+	s := newSpa(n)
+	err := NotConverged
+	for it := 0; it < m.MaxIter; it++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+			break
+		}
+		next := f.clone()
+		for p := 1; p < m.Expansion; p++ {
+			next = next.mul(f, s)
+		}
+		for j := range next {
+			next[j] = next[j].inflate(m.Inflation).prune(m.Prune)
+			next[j].normalize()
+		}
+		converged := next.maxDiff(f, s) <= m.Tolerance
+		f = next
+		if converged {
+			err = nil
+			break
+		}
+	}
+
+	// Nodes are joined to the attractors that they flow to.
+	uf := newUnionFind(n)
+	for j, col := range f {
+		for _, e := range col {
+			uf.union(e.i, j)
+		}
+	}
+	byRoot := make(map[int]int)
+	var clusters []Nodes
+	for i := 0; i < n; i++ {
+		r := uf.find(i)
+		c, ok := byRoot[r]
+		if !ok {
+			c = len(clusters)
+			byRoot[r] = c
+			clusters = append(clusters, nil)
+		}
+		clusters[c] = append(clusters[c], g.Node(ix.ID(i)))
+	}
+
+	return clusters, err
+}
+
+// entry is an element of a sparse column.
+type entry struct {
+	i int
+	v float64
+}
+
+// column is a sparse matrix column with entries in ascending row order.
+type column []entry
+
+// sparse is a sparse matrix held as columns.
+type sparse []column
+
+// merge sorts the entries of c by row and sums entries with the same row.
+func (c column) merge() column {
+	sort.Slice(c, func(a, b int) bool { return c[a].i < c[b].i })
+	var k int
+	for _, e := range c {
+		if k > 0 && c[k-1].i == e.i {
+			c[k-1].v += e.v
+			continue
+		}
+		c[k] = e
+		k++
+	}
+	return c[:k]
+}
+
+func (c column) normalize() {
+	var sum float64
+	for _, e := range c {
+		sum += e.v
+	}
+	if sum == 0 {
+		return
+	}
+	for k := range c {
+		c[k].v /= sum
+	}
+}
+
+func (c column) inflate(r float64) column {
+	for k := range c {
+		c[k].v = math.Pow(c[k].v, r)
+	}
+	return c
+}
+
+// prune removes entries of c less than t, keeping the largest entry if all would be removed.
+func (c column) prune(t float64) column {
+	if len(c) == 0 {
+		return c
+	}
+	max := 0
+	for k, e := range c {
+		if e.v > c[max].v {
+			max = k
+		}
+	}
+	var k int
+	for j, e := range c {
+		if e.v >= t || j == max {
+			c[k] = e
+			k++
+		}
+	}
+	return c[:k]
+}
+
+// spa is a sparse accumulator for building columns.
+type spa struct {
+	val     []float64
+	touched []bool
+	rows    []int
+}
+
+func newSpa(n int) *spa {
+	return &spa{val: make([]float64, n), touched: make([]bool, n)}
+}
+
+func (s *spa) add(i int, v float64) {
+	if !s.touched[i] {
+		s.touched[i] = true
+		s.rows = append(s.rows, i)
+	}
+	s.val[i] += v
+}
+
+// column returns the accumulated column and resets the accumulator.
+func (s *spa) column() column {
+	sort.Ints(s.rows)
+	c := make(column, len(s.rows))
+	for k, i := range s.rows {
+		c[k] = entry{i, s.val[i]}
+		s.val[i] = 0
+		s.touched[i] = false
+	}
+	s.rows = s.rows[:0]
+	return c
+}
+
+func (a sparse) clone() sparse {
+	c := make(sparse, len(a))
+	for j, col := range a {
+		c[j] = append(column(nil), col...)
+	}
+	return c
+}
+
+// mul returns the matrix product a×b.
+func (a sparse) mul(b sparse, s *spa) sparse {
+	p := make(sparse, len(b))
+	for j, col := range b {
+		for _, e := range col {
+			for _, ae := range a[e.i] {
+				s.add(ae.i, ae.v*e.v)
+			}
+		}
+		p[j] = s.column()
+	}
+	return p
+}
+
+// maxDiff returns the greatest absolute difference between elements of a and b.
+func (a sparse) maxDiff(b sparse, s *spa) float64 {
+	var max float64
+	for j := range a {
+		for _, e := range a[j] {
+			s.add(e.i, e.v)
+		}
+		for _, e := range b[j] {
+			s.add(e.i, -e.v)
+		}
+		for _, e := range s.column() {
+			max = math.Max(max, math.Abs(e.v))
+		}
+	}
+	return max
+}
+
+// unionFind is a disjoint set forest over integers.
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	u := make(unionFind, n)
+	for i := range u {
+		u[i] = i
+	}
+	return u
+}
+
+func (u unionFind) find(i int) int {
+	for u[i] != i {
+		u[i] = u[u[i]]
+		i = u[i]
+	}
+	return i
+}
+
+func (u unionFind) union(i, j int) {
+	ri, rj := u.find(i), u.find(j)
+	if ri != rj {
+		u[rj] = ri
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"context"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestMarkovCluster(c *check.C) {
+	mcl := NewMarkovCluster()
+	cl, err := mcl.Cluster(ringOfCliques(6, 5))
+	c.Assert(err, check.Equals, nil)
+	c.Assert(len(cl), check.Equals, 6)
+	for i, ns := range cl {
+		c.Check(len(ns), check.Equals, 5)
+		for _, n := range ns {
+			c.Check(n.ID()/5, check.Equals, i)
+		}
+	}
+
+	// Disconnected nodes form singleton clusters.
+	g := Complete(4)
+	g.AddID(4)
+	cl, err = mcl.Cluster(g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(len(cl), check.Equals, 2)
+	c.Check(len(cl[1]), check.Equals, 1)
+
+	// Heavy edges hold clusters together across weak edges.
+	g = reweight(Path(6), func(u, v int) float64 {
+		if u%2 == 0 {
+			return 10
+		}
+		return 1
+	})
+	cl, err = mcl.Cluster(g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(len(cl), check.Equals, 3)
+	mcl.Weighted = false
+	cl, _ = mcl.Cluster(g)
+	c.Check(len(cl), check.Not(check.Equals), 3)
+
+	mcl = NewMarkovCluster()
+	mcl.MaxIter = 1
+	_, err = mcl.Cluster(ringOfCliques(6, 5))
+	c.Check(err, check.Equals, NotConverged)
+}
+
+func (s *S) TestMarkovClusterContext(c *check.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cl, err := NewMarkovCluster().ClusterContext(ctx, ringOfCliques(3, 4))
+	c.Check(err, check.Equals, context.Canceled)
+
+	// Without any iterations every node is joined to its
+	// neighbors.
+	c.Check(len(cl), check.Equals, 1)
+}
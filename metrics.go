@@ -0,0 +1,116 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"errors"
+	"math"
+)
+
+// Disconnected is returned when a calculation requires a connected graph.
+var Disconnected = errors.New("graph: graph is disconnected")
+
+// Eccentricity returns the eccentricity of each node in g, keyed by node ID. The eccentricity of
+// a node is the greatest shortest path distance from the node to any other node. If weighted is
+// true, edge weights are used as path lengths and must be positive, otherwise each edge has
+// length 1; a zero, negative or NaN weight causes a panic. If g is disconnected, the eccentricity
+// of each node within its own connected component is returned with the error Disconnected.
+func Eccentricity(g *Undirected, weighted bool) (map[int]float64, error) {
+	ix, ecc, err := eccentricities(g, weighted)
+	e := make(map[int]float64, len(ecc))
+	for i, v := range ecc {
+		e[ix.ID(i)] = v
+	}
+
+	return e, err
+}
+
+// Diameter returns the greatest eccentricity of the nodes in g, with edge lengths as for
+// Eccentricity. If g is disconnected, Diameter returns +Inf and the error Disconnected. The
+// diameter of a graph with no nodes is 0.
+func Diameter(g *Undirected, weighted bool) (float64, error) {
+	_, ecc, err := eccentricities(g, weighted)
+	if err != nil {
+		return math.Inf(1), err
+	}
+	var d float64
+	for _, v := range ecc {
+		d = math.Max(d, v)
+	}
+
+	return d, nil
+}
+
+// Radius returns the least eccentricity of the nodes in g, with edge lengths as for
+// Eccentricity. If g is disconnected, Radius returns +Inf and the error Disconnected. The radius
+// of a graph with no nodes is 0.
+func Radius(g *Undirected, weighted bool) (float64, error) {
+	_, ecc, err := eccentricities(g, weighted)
+	if err != nil {
+		return math.Inf(1), err
+	}
+	if len(ecc) == 0 {
+		return 0, nil
+	}
+	r := math.Inf(1)
+	for _, v := range ecc {
+		r = math.Min(r, v)
+	}
+
+	return r, nil
+}
+
+// Center returns the nodes of g with eccentricity equal to the radius, in ascending order of ID.
+// If g is disconnected, Center returns nil and the error Disconnected.
+func Center(g *Undirected, weighted bool) (Nodes, error) {
+	return extremal(g, weighted, math.Min)
+}
+
+// Periphery returns the nodes of g with eccentricity equal to the diameter, in ascending order of
+// ID. If g is disconnected, Periphery returns nil and the error Disconnected.
+func Periphery(g *Undirected, weighted bool) (Nodes, error) {
+	return extremal(g, weighted, math.Max)
+}
+
+// extremal returns the nodes of g with the eccentricity chosen by repeated application of
+// choose.
+func extremal(g *Undirected, weighted bool, choose func(a, b float64) float64) (Nodes, error) {
+	ix, ecc, err := eccentricities(g, weighted)
+	if err != nil || len(ecc) == 0 {
+		return nil, err
+	}
+	e := ecc[0]
+	for _, v := range ecc[1:] {
+		e = choose(e, v)
+	}
+	var ns Nodes
+	for i, v := range ecc {
+		if v == e {
+			ns = append(ns, g.Node(ix.ID(i)))
+		}
+	}
+
+	return ns, nil
+}
+
+// eccentricities returns the eccentricity of each row of ix within its connected component, and
+// the error Disconnected if any node does not reach every other node.
+func eccentricities(g *Undirected, weighted bool) (*NodeIndex, []float64, error) {
+	ix := NewNodeIndex(g)
+	t := newPathTree(g, weighted)
+	ecc := make([]float64, ix.Len())
+	var err error
+	for i := range ecc {
+		t.from(g.Node(ix.ID(i)))
+		for _, v := range t.order {
+			ecc[i] = math.Max(ecc[i], t.dist[v.ID()])
+		}
+		if len(t.order) < len(ecc) {
+			err = Disconnected
+		}
+	}
+
+	return ix, ecc, err
+}
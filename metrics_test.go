@@ -0,0 +1,81 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+
+	"gopkg.in/check.v1"
+)
+
+// nodeIDs returns the IDs of ns.
+func nodeIDs(ns Nodes) []int {
+	var ids []int
+	for _, n := range ns {
+		ids = append(ids, n.ID())
+	}
+	return ids
+}
+
+func (s *S) TestEccentricity(c *check.C) {
+	g := Path(5)
+	e, err := Eccentricity(g, false)
+	c.Check(err, check.IsNil)
+	c.Check(e, check.DeepEquals, map[int]float64{0: 4, 1: 3, 2: 2, 3: 3, 4: 4})
+
+	d, err := Diameter(g, false)
+	c.Check(err, check.IsNil)
+	c.Check(d, check.Equals, 4.)
+	r, err := Radius(g, false)
+	c.Check(err, check.IsNil)
+	c.Check(r, check.Equals, 2.)
+	ce, err := Center(g, false)
+	c.Check(err, check.IsNil)
+	c.Check(nodeIDs(ce), check.DeepEquals, []int{2})
+	p, err := Periphery(g, false)
+	c.Check(err, check.IsNil)
+	c.Check(nodeIDs(p), check.DeepEquals, []int{0, 4})
+
+	d, err = Diameter(Petersen(), false)
+	c.Check(err, check.IsNil)
+	c.Check(d, check.Equals, 2.)
+
+	d, err = Diameter(NewUndirected(), false)
+	c.Check(err, check.IsNil)
+	c.Check(d, check.Equals, 0.)
+}
+
+func (s *S) TestEccentricityWeighted(c *check.C) {
+	// Path 0-1-2-3 with a heavy middle edge.
+	g := reweight(Path(4), func(u, v int) float64 {
+		if u+v == 3 {
+			return 5
+		}
+		return 1
+	})
+	e, err := Eccentricity(g, true)
+	c.Check(err, check.IsNil)
+	c.Check(e, check.DeepEquals, map[int]float64{0: 7, 1: 6, 2: 6, 3: 7})
+	ce, err := Center(g, true)
+	c.Check(err, check.IsNil)
+	c.Check(nodeIDs(ce), check.DeepEquals, []int{1, 2})
+}
+
+func (s *S) TestEccentricityDisconnected(c *check.C) {
+	g := Path(3)
+	g.AddID(3)
+	g.AddID(4)
+	g.ConnectByID(3, 4)
+	e, err := Eccentricity(g, false)
+	c.Check(err, check.Equals, Disconnected)
+	c.Check(e, check.DeepEquals, map[int]float64{0: 2, 1: 1, 2: 2, 3: 1, 4: 1})
+
+	d, err := Diameter(g, false)
+	c.Check(err, check.Equals, Disconnected)
+	c.Check(math.IsInf(d, 1), check.Equals, true)
+	ce, err := Center(g, false)
+	c.Check(err, check.Equals, Disconnected)
+	c.Check(ce, check.IsNil)
+}
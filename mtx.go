@@ -0,0 +1,179 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLine is the length of the longest line accepted by the text format readers.
+const maxLine = 1 << 30
+
+// ReadMatrixMarket reads a square sparse matrix in Matrix Market coordinate format from r and
+// returns the Undirected graph it describes. Real, integer and pattern fields are supported with
+// general or symmetric symmetry. Row i of the matrix is represented by the node with ID i-1 and
+// each entry by an edge weighted with the entry's value, or 1 for pattern matrices. Entries on
+// the diagonal are represented by self-loops. In general matrices, an entry below the diagonal
+// that mirrors an entry above the diagonal is taken to describe the same edge, and a NotSymmetric
+// error is returned if the two entries' values differ.
+func ReadMatrixMarket(r io.Reader) (*Undirected, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxLine)
+	var line int
+	next := func() (string, bool) {
+		for sc.Scan() {
+			line++
+			l := strings.TrimSpace(sc.Text())
+			if l == "" || strings.HasPrefix(l, "%") {
+				continue
+			}
+			return l, true
+		}
+		return "", false
+	}
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("graph: matrix market line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("graph: matrix market: missing header")
+	}
+	line++
+	h := strings.Fields(strings.ToLower(sc.Text()))
+	if len(h) != 5 || h[0] != "%%matrixmarket" || h[1] != "matrix" {
+		return nil, errorf("invalid header")
+	}
+	if h[2] != "coordinate" {
+		return nil, errorf("unsupported format: %s", h[2])
+	}
+	pattern := false
+	switch h[3] {
+	case "real", "integer":
+	case "pattern":
+		pattern = true
+	default:
+		return nil, errorf("unsupported field: %s", h[3])
+	}
+	symmetric := false
+	switch h[4] {
+	case "general":
+	case "symmetric":
+		symmetric = true
+	default:
+		return nil, errorf("unsupported symmetry: %s", h[4])
+	}
+
+	l, ok := next()
+	if !ok {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errorf("missing size line")
+	}
+	var rows, cols, nnz int
+	if _, err := fmt.Sscan(l, &rows, &cols, &nnz); err != nil {
+		return nil, errorf("invalid size line: %v", err)
+	}
+	if rows < 0 || cols < 0 || nnz < 0 {
+		return nil, errorf("invalid size line: negative size")
+	}
+	if rows != cols {
+		return nil, NotSquare
+	}
+
+	g := NewUndirected()
+	for i := 0; i < rows; i++ {
+		g.AddID(i)
+	}
+
+	type entry struct {
+		i, j int
+		w    float64
+	}
+	var lower []entry
+	upper := make(map[[2]int][]float64)
+	for k := 0; k < nnz; k++ {
+		l, ok = next()
+		if !ok {
+			if err := sc.Err(); err != nil {
+				return nil, err
+			}
+			return nil, errorf("expected %d entries, found %d", nnz, k)
+		}
+		f := strings.Fields(l)
+		if len(f) < 2 || (!pattern && len(f) < 3) {
+			return nil, errorf("invalid entry")
+		}
+		i, err := strconv.Atoi(f[0])
+		if err != nil {
+			return nil, errorf("invalid row: %v", err)
+		}
+		j, err := strconv.Atoi(f[1])
+		if err != nil {
+			return nil, errorf("invalid column: %v", err)
+		}
+		if i < 1 || i > rows || j < 1 || j > cols {
+			return nil, errorf("entry (%d, %d) out of range", i, j)
+		}
+		w := 1.
+		if !pattern {
+			w, err = strconv.ParseFloat(f[2], 64)
+			if err != nil {
+				return nil, errorf("invalid value: %v", err)
+			}
+		}
+		i--
+		j--
+		if !symmetric && i > j {
+			lower = append(lower, entry{i, j, w})
+			continue
+		}
+		if !symmetric && i < j {
+			upper[[2]int{i, j}] = append(upper[[2]int{i, j}], w)
+		}
+		g.connectIDs(i, j, w)
+	}
+	for _, e := range lower {
+		if k := [2]int{e.j, e.i}; len(upper[k]) > 0 {
+			if upper[k][0] != e.w {
+				return nil, NotSymmetric
+			}
+			upper[k] = upper[k][1:]
+			continue
+		}
+		g.connectIDs(e.i, e.j, e.w)
+	}
+
+	return g, sc.Err()
+}
+
+// WriteMatrixMarket writes g to w as a real symmetric matrix in Matrix Market coordinate format.
+// Rows are assigned to nodes in ascending order of node ID as described by NewNodeIndex, and each
+// edge is written as a single entry holding its weight on or below the diagonal.
+func WriteMatrixMarket(w io.Writer, g *Undirected) error {
+	ix := NewNodeIndex(g)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "%%MatrixMarket matrix coordinate real symmetric")
+	fmt.Fprintf(bw, "%d %d %d\n", ix.Len(), ix.Len(), g.Size())
+	for _, e := range g.edges {
+		if e == nil {
+			continue
+		}
+		i, j := ix.Row(e.Tail().ID())+1, ix.Row(e.Head().ID())+1
+		if i < j {
+			i, j = j, i
+		}
+		fmt.Fprintf(bw, "%d %d %s\n", i, j, strconv.FormatFloat(e.Weight(), 'g', -1, 64))
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,69 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestMatrixMarketRoundTrip(c *check.C) {
+	g := undirected(c, uv)
+	g.ConnectWith(g.Node(2), g.Node(2), NewWeightedEdge(0.5))
+	g.ConnectWith(g.Node(1), g.Node(4), NewWeightedEdge(2.25))
+
+	var buf bytes.Buffer
+	c.Assert(WriteMatrixMarket(&buf, g), check.Equals, nil)
+	g0, err := ReadMatrixMarket(&buf)
+	c.Assert(err, check.Equals, nil)
+	c.Check(g0.Order(), check.Equals, g.Order())
+	c.Check(g0.Size(), check.Equals, g.Size())
+	a, _ := AdjacencyMatrix(g)
+	a0, _ := AdjacencyMatrix(g0)
+	c.Check(a0, check.DeepEquals, a)
+}
+
+func (s *S) TestReadMatrixMarket(c *check.C) {
+	const general = `%%MatrixMarket matrix coordinate pattern general
+% a path with one edge given in both directions
+4 4 4
+1 2
+2 1
+3 2
+4 3
+`
+	g, err := ReadMatrixMarket(strings.NewReader(general))
+	c.Assert(err, check.Equals, nil)
+	c.Check(g.Order(), check.Equals, 4)
+	c.Check(g.Size(), check.Equals, 3)
+
+	for _, bad := range []string{
+		"%%MatrixMarket matrix array real general\n2 2\n1\n0\n0\n1\n",
+		"%%MatrixMarket matrix coordinate complex general\n2 2 1\n1 2 1 0\n",
+		"%%MatrixMarket matrix coordinate real general\n2 3 1\n1 2 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 2 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 3 1\n",
+		"%%MatrixMarket matrix coordinate real general\n-1 -1 0\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 -1\n",
+	} {
+		_, err = ReadMatrixMarket(strings.NewReader(bad))
+		c.Check(err, check.Not(check.Equals), nil)
+	}
+
+	const asymmetric = `%%MatrixMarket matrix coordinate real general
+2 2 2
+1 2 1
+2 1 2
+`
+	_, err = ReadMatrixMarket(strings.NewReader(asymmetric))
+	c.Check(err, check.Equals, NotSymmetric)
+
+	long := "%%MatrixMarket matrix coordinate pattern general\n%" + strings.Repeat(" ", 1<<17) + "\n2 2 1\n1 2\n"
+	g, err = ReadMatrixMarket(strings.NewReader(long))
+	c.Assert(err, check.Equals, nil)
+	c.Check(g.Size(), check.Equals, 1)
+}
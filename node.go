@@ -31,6 +31,7 @@ type node struct {
 	id    int
 	i     int
 	edges Edges
+	attrs attributes
 }
 
 // newNode creates a new *Nodes with ID id. Nodes should only ever exist in the context of a
@@ -158,9 +159,9 @@ func (ns Nodes) BuildUndirected(compact bool) (*Undirected, error) {
 			g.AddID(vid)
 			var ne Edge
 			if compact {
-				ne = g.newEdge(g.nodes[uid], g.nodes[vid])
+				ne = g.newEdge(g.nodes[uid], g.nodes[vid], e.Weight())
 			} else {
-				ne = g.newEdgeKeepID(e.ID(), g.nodes[uid], g.nodes[vid])
+				ne = g.newEdgeKeepID(e.ID(), g.nodes[uid], g.nodes[vid], e.Weight())
 			}
 			g.nodes[uid].add(ne)
 			if vid != uid {
@@ -0,0 +1,184 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	BadDistribution = errors.New("graph: distribution must be finite and non-negative with a positive sum")
+	BadWeight       = errors.New("graph: edge weight must be finite and non-negative")
+	BadDamping      = errors.New("graph: damping out of range")
+)
+
+// A PageRanker calculates PageRank and personalized PageRank scores for the nodes of a graph.
+type PageRanker struct {
+	// Damping is the probability that the random walk follows
+	// an edge rather than jumping to a node chosen from the
+	// personalization distribution. It must be in [0, 1].
+	Damping float64
+
+	// Tolerance and MaxIter control convergence. Iteration
+	// stops when the sum of absolute changes in rank is less
+	// than n·Tolerance.
+	Tolerance float64
+	MaxIter   int
+
+	// Weighted specifies whether edge weights are used as
+	// transition weights. Otherwise each edge has weight 1.
+	// Weights must be finite and not negative. Edges with weight 0 are
+	// never followed, so nodes whose edges all have weight 0
+	// are treated as having no outgoing edges.
+	Weighted bool
+
+	// Directed specifies whether each edge is treated as an
+	// arc from its tail to its head. Otherwise edges may be
+	// followed in either direction.
+	Directed bool
+
+	// Personalization holds the relative probability of
+	// jumping to each node, keyed by node ID. Nodes not in
+	// the map have zero probability. If Personalization is
+	// nil, jumps are uniformly distributed.
+	Personalization map[int]float64
+
+	// Dangling holds the relative probability of moving to
+	// each node, keyed by node ID, from nodes without outgoing
+	// edges. If Dangling is nil, the Personalization
+	// distribution is used.
+	Dangling map[int]float64
+}
+
+// NewPageRanker returns a PageRanker with a damping of 0.85, tolerance of 1e-6 and a limit
+// of 100 iterations.
+func NewPageRanker() *PageRanker {
+	return &PageRanker{Damping: 0.85, Tolerance: 1e-6, MaxIter: 100}
+}
+
+// Rank returns the PageRank of each node in g, keyed by node ID. Ranks sum to 1. If the
+// calculation does not converge within MaxIter iterations, the last estimate is returned with
+// the error NotConverged. The errors BadDamping, BadDistribution and BadWeight are returned
+// if Damping, the Personalization or Dangling distributions or the edge weights are invalid.
+func (p *PageRanker) Rank(g *Undirected) (map[int]float64, error) {
+	if !(p.Damping >= 0 && p.Damping <= 1) {
+		return nil, BadDamping
+	}
+	ix := NewNodeIndex(g)
+	n := ix.Len()
+	rank := make(map[int]float64, n)
+	if n == 0 {
+		return rank, nil
+	}
+
+	jump, err := distribution(ix, p.Personalization)
+	if err != nil {
+		return nil, err
+	}
+	dangling := jump
+	if p.Dangling != nil {
+		dangling, err = distribution(ix, p.Dangling)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	type arc struct {
+		u, v int
+		w    float64
+	}
+	arcs := make([]arc, 0, 2*g.Size())
+	out := make([]float64, n)
+	for _, e := range g.Edges() {
+		w := 1.
+		if p.Weighted {
+			w = e.Weight()
+			if !(w >= 0) || math.IsInf(w, 1) {
+				return nil, BadWeight
+			}
+			if w == 0 {
+				continue
+			}
+		}
+		u, v := ix.Row(e.Tail().ID()), ix.Row(e.Head().ID())
+		arcs = append(arcs, arc{u, v, w})
+		out[u] += w
+		if !p.Directed && u != v {
+			arcs = append(arcs, arc{v, u, w})
+			out[v] += w
+		}
+	}
+
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	err = NotConverged
+	for i := 0; i < p.MaxIter; i++ {
+		var lost float64
+		for j, w := range out {
+			if w == 0 {
+				lost += x[j]
+			}
+		}
+		for j := range next {
+			next[j] = p.Damping*lost*dangling[j] + (1-p.Damping)*jump[j]
+		}
+		for _, a := range arcs {
+			next[a.v] += p.Damping * x[a.u] * a.w / out[a.u]
+		}
+
+		var delta float64
+		for j := range next {
+			delta += math.Abs(next[j] - x[j])
+		}
+		x, next = next, x
+		if delta < float64(n)*p.Tolerance {
+			err = nil
+			break
+		}
+	}
+
+	for i, v := range x {
+		rank[ix.ID(i)] = v
+	}
+
+	return rank, err
+}
+
+// distribution returns the normalized distribution over the rows of ix described by d,
+// or the uniform distribution if d is nil.
+func distribution(ix *NodeIndex, d map[int]float64) ([]float64, error) {
+	p := make([]float64, ix.Len())
+	if d == nil {
+		for i := range p {
+			p[i] = 1 / float64(len(p))
+		}
+		return p, nil
+	}
+
+	var sum float64
+	for id, v := range d {
+		if !(v >= 0) || math.IsInf(v, 1) {
+			return nil, BadDistribution
+		}
+		i := ix.Row(id)
+		if i < 0 {
+			return nil, NodeDoesNotExist
+		}
+		p[i] = v
+		sum += v
+	}
+	if !(sum > 0) || math.IsInf(sum, 1) {
+		return nil, BadDistribution
+	}
+	for i := range p {
+		p[i] /= sum
+	}
+
+	return p, nil
+}
@@ -0,0 +1,116 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+
+	"gopkg.in/check.v1"
+)
+
+func sum(m map[int]float64) float64 {
+	var s float64
+	for _, v := range m {
+		s += v
+	}
+	return s
+}
+
+func (s *S) TestPageRank(c *check.C) {
+	pr := NewPageRanker()
+	pr.Tolerance = 1e-10
+	pr.MaxIter = 1000
+
+	r, err := pr.Rank(Cycle(5))
+	c.Assert(err, check.Equals, nil)
+	for _, v := range r {
+		c.Check(math.Abs(v-0.2) < 1e-9, check.Equals, true)
+	}
+
+	r, err = pr.Rank(Star(4))
+	c.Assert(err, check.Equals, nil)
+	c.Check(math.Abs(sum(r)-1) < 1e-9, check.Equals, true)
+	c.Check(r[0] > r[1], check.Equals, true)
+
+	// In the directed path 0→1→2 the rank of node 2 is dangling and is
+	// redistributed uniformly, so the ranks satisfy
+	//  r0 = (1-d)/3 + d·r2/3
+	//  r1 = r0 + d·r0
+	//  r2 = r0 + d·r1
+	pr.Directed = true
+	r, err = pr.Rank(Path(3))
+	c.Assert(err, check.Equals, nil)
+	d := pr.Damping
+	c.Check(math.Abs(r[0]-((1-d)/3+d*r[2]/3)) < 1e-9, check.Equals, true)
+	c.Check(math.Abs(r[1]-(1+d)*r[0]) < 1e-9, check.Equals, true)
+	c.Check(math.Abs(r[2]-(r[0]+d*r[1])) < 1e-9, check.Equals, true)
+	c.Check(math.Abs(sum(r)-1) < 1e-9, check.Equals, true)
+
+	// Weights bias the walk.
+	pr.Directed = false
+	g := Path(3)
+	g.DeleteEdge(g.Edge(1))
+	g.ConnectWith(g.Node(1), g.Node(2), NewWeightedEdge(10))
+	pr.Weighted = true
+	r, err = pr.Rank(g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(r[2] > r[0], check.Equals, true)
+
+	pr.MaxIter = 1
+	_, err = pr.Rank(g)
+	c.Check(err, check.Equals, NotConverged)
+}
+
+func (s *S) TestPersonalizedPageRank(c *check.C) {
+	pr := NewPageRanker()
+	pr.Personalization = map[int]float64{0: 1}
+	g := Path(5)
+	r, err := pr.Rank(g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(math.Abs(sum(r)-1) < 1e-6, check.Equals, true)
+	c.Check(r[1] > r[3], check.Equals, true)
+	c.Check(r[0] > r[4], check.Equals, true)
+
+	for _, d := range []map[int]float64{{0: 0}, {0: -1}, {0: math.NaN()}, {0: math.Inf(1)}} {
+		pr.Personalization = d
+		_, err = pr.Rank(g)
+		c.Check(err, check.Equals, BadDistribution)
+	}
+	pr.Personalization = map[int]float64{10: 1}
+	_, err = pr.Rank(g)
+	c.Check(err, check.Equals, NodeDoesNotExist)
+}
+
+func (s *S) TestPageRankZeroWeight(c *check.C) {
+	pr := NewPageRanker()
+	pr.Weighted = true
+
+	// Nodes with only zero weight edges are dangling.
+	g := reweight(Path(2), func(u, v int) float64 { return 0 })
+	r, err := pr.Rank(g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(r, check.DeepEquals, map[int]float64{0: 0.5, 1: 0.5})
+
+	g = reweight(Path(3), func(u, v int) float64 { return float64(u) })
+	r, err = pr.Rank(g)
+	c.Assert(err, check.Equals, nil)
+	c.Check(math.Abs(sum(r)-1) < 1e-6, check.Equals, true)
+	for _, v := range r {
+		c.Check(math.IsNaN(v), check.Equals, false)
+	}
+
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		g = reweight(Path(2), func(u, v int) float64 { return w })
+		_, err = pr.Rank(g)
+		c.Check(err, check.Equals, BadWeight)
+	}
+
+	pr = NewPageRanker()
+	for _, d := range []float64{-0.1, 1.1, math.NaN()} {
+		pr.Damping = d
+		_, err = pr.Rank(Path(3))
+		c.Check(err, check.Equals, BadDamping)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadPajek reads a network in Pajek .net format from r and returns the Undirected graph it
+// describes. Vertex n is represented by the node with ID n-1. Lines in *Edges and *Arcs sections
+// are represented by edges weighted with the optional third field, or 1 if it is absent; arcs are
+// read as undirected edges. *Edgeslist and *Arcslist sections are read as unweighted edges from
+// the first vertex of each line to each of the following vertices. *Matrix sections are read as
+// the rows of an adjacency matrix, each nonzero element being represented by an edge weighted with
+// its value. As for ReadMatrixMarket, a nonzero element below the diagonal that mirrors a nonzero
+// element above the diagonal is taken to describe the same edge, a NotSymmetric error being
+// returned if their values differ, and elements on the diagonal are represented by self-loops.
+// Vertex labels, coordinates and other line attributes are ignored.
+func ReadPajek(r io.Reader) (*Undirected, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxLine)
+	var line int
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("graph: pajek line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	var (
+		g       *Undirected
+		section string
+
+		// pos is the number of elements read in the current
+		// matrix section and upper records the nonzero
+		// elements above its diagonal.
+		pos   int
+		upper map[[2]int]float64
+	)
+	endMatrix := func() error {
+		if section == "*matrix" && pos != g.Order()*g.Order() {
+			return errorf("expected %d matrix elements, found %d", g.Order()*g.Order(), pos)
+		}
+		return nil
+	}
+	vertex := func(f string) (int, error) {
+		id, err := strconv.Atoi(f)
+		if err != nil {
+			return -1, errorf("invalid vertex: %v", err)
+		}
+		if g == nil || id < 1 || id > g.Order() {
+			return -1, errorf("vertex %d out of range", id)
+		}
+		return id - 1, nil
+	}
+	for sc.Scan() {
+		line++
+		l := strings.TrimSpace(sc.Text())
+		if l == "" || strings.HasPrefix(l, "%") {
+			continue
+		}
+		f := strings.Fields(l)
+		if strings.HasPrefix(l, "*") {
+			if err := endMatrix(); err != nil {
+				return nil, err
+			}
+			section = strings.ToLower(f[0])
+			switch section {
+			case "*network":
+			case "*vertices":
+				if g != nil {
+					return nil, errorf("repeated vertices section")
+				}
+				if len(f) < 2 {
+					return nil, errorf("missing vertex count")
+				}
+				n, err := strconv.Atoi(f[1])
+				if err != nil || n < 0 {
+					return nil, errorf("invalid vertex count: %q", f[1])
+				}
+				g = NewUndirected()
+				for i := 0; i < n; i++ {
+					g.AddID(i)
+				}
+			case "*edges", "*arcs", "*edgeslist", "*arcslist", "*matrix":
+				if g == nil {
+					return nil, errorf("%s section before vertices section", f[0])
+				}
+				pos = 0
+				upper = make(map[[2]int]float64)
+			default:
+				return nil, errorf("unsupported section: %s", f[0])
+			}
+			continue
+		}
+
+		switch section {
+		case "*vertices":
+			if _, err := vertex(f[0]); err != nil {
+				return nil, err
+			}
+		case "*edges", "*arcs":
+			if len(f) < 2 {
+				return nil, errorf("invalid line")
+			}
+			u, err := vertex(f[0])
+			if err != nil {
+				return nil, err
+			}
+			v, err := vertex(f[1])
+			if err != nil {
+				return nil, err
+			}
+			w := 1.
+			if len(f) > 2 {
+				w, err = strconv.ParseFloat(f[2], 64)
+				if err != nil {
+					return nil, errorf("invalid weight: %v", err)
+				}
+			}
+			g.connectIDs(u, v, w)
+		case "*edgeslist", "*arcslist":
+			u, err := vertex(f[0])
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range f[1:] {
+				v, err := vertex(t)
+				if err != nil {
+					return nil, err
+				}
+				g.connectIDs(u, v, 1)
+			}
+		case "*matrix":
+			n := g.Order()
+			for _, t := range f {
+				if pos == n*n {
+					return nil, errorf("too many matrix elements")
+				}
+				w, err := strconv.ParseFloat(t, 64)
+				if err != nil {
+					return nil, errorf("invalid matrix element: %v", err)
+				}
+				i, j := pos/n, pos%n
+				pos++
+				switch {
+				case w == 0:
+				case i < j:
+					upper[[2]int{i, j}] = w
+					g.connectIDs(i, j, w)
+				case i > j && upper[[2]int{j, i}] != 0:
+					if upper[[2]int{j, i}] != w {
+						return nil, NotSymmetric
+					}
+				default:
+					g.connectIDs(i, j, w)
+				}
+			}
+		default:
+			return nil, errorf("unexpected line outside section")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if err := endMatrix(); err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, fmt.Errorf("graph: pajek: missing vertices section")
+	}
+
+	return g, nil
+}
+
+// WritePajek writes g to w in Pajek .net format. Vertices are numbered in ascending order of node
+// ID as described by NewNodeIndex and are labelled with their node ID. Each edge is written to
+// the *Edges section with its weight.
+func WritePajek(w io.Writer, g *Undirected) error {
+	ix := NewNodeIndex(g)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "*Vertices %d\n", ix.Len())
+	for i := 0; i < ix.Len(); i++ {
+		fmt.Fprintf(bw, "%d \"%d\"\n", i+1, ix.ID(i))
+	}
+	fmt.Fprintln(bw, "*Edges")
+	for _, e := range g.edges {
+		if e == nil {
+			continue
+		}
+		fmt.Fprintf(bw, "%d %d %s\n",
+			ix.Row(e.Tail().ID())+1, ix.Row(e.Head().ID())+1,
+			strconv.FormatFloat(e.Weight(), 'g', -1, 64),
+		)
+	}
+
+	return bw.Flush()
+}
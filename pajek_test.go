@@ -0,0 +1,103 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestPajekRoundTrip(c *check.C) {
+	g := undirected(c, uv)
+	g.ConnectWith(g.Node(2), g.Node(2), NewWeightedEdge(0.5))
+
+	var buf bytes.Buffer
+	c.Assert(WritePajek(&buf, g), check.Equals, nil)
+	g0, err := ReadPajek(&buf)
+	c.Assert(err, check.Equals, nil)
+	c.Check(g0.Order(), check.Equals, g.Order())
+	c.Check(g0.Size(), check.Equals, g.Size())
+	a, _ := AdjacencyMatrix(g)
+	a0, _ := AdjacencyMatrix(g0)
+	c.Check(a0, check.DeepEquals, a)
+}
+
+func (s *S) TestReadPajek(c *check.C) {
+	const net = `% a small network
+*Network test
+*Vertices 5
+1 "a b" 0.1 0.2 0.3
+2 "c"
+*Arcs
+1 2 2.5 c Blue
+*Edges
+2 3
+*Edgeslist
+3 4 5
+`
+	g, err := ReadPajek(strings.NewReader(net))
+	c.Assert(err, check.Equals, nil)
+	c.Check(g.Order(), check.Equals, 5)
+	c.Check(g.Size(), check.Equals, 4)
+	c.Check(g.Edge(0).Weight(), check.Equals, 2.5)
+	c.Check(g.Edge(3).Head().ID(), check.Equals, 4)
+
+	for _, bad := range []string{
+		"*Edges\n1 2\n",
+		"*Vertices 2\n*Edges\n1 3\n",
+		"*Vertices 2\n*Matrix\n0 1\n1\n",
+		"*Vertices 2\n*Matrix\n0 1\n1 0 1\n",
+		"*Vertices 2\n*Matrix\n0 x\n1 0\n",
+		"*Vertices 2\n*Edges\n1 2 x\n",
+	} {
+		_, err = ReadPajek(strings.NewReader(bad))
+		c.Check(err, check.Not(check.Equals), nil)
+	}
+}
+
+func (s *S) TestReadPajekMatrix(c *check.C) {
+	// The third row is continued on the next line.
+	const net = `*Vertices 4
+*Matrix
+0 2 0 1
+2 0.5 0 0
+0 0 0
+3 1 0 0 0
+*Edges
+3 4
+`
+	g, err := ReadPajek(strings.NewReader(net))
+	c.Assert(err, check.Equals, nil)
+	c.Check(g.Order(), check.Equals, 4)
+	c.Check(g.Size(), check.Equals, 5)
+	a, _ := AdjacencyMatrix(g)
+	c.Check(a.Data, check.DeepEquals, []float64{
+		0, 2, 0, 1,
+		2, 1, 0, 0,
+		0, 0, 0, 4,
+		1, 0, 4, 0,
+	})
+
+	_, err = ReadPajek(strings.NewReader("*Vertices 2\n*Matrix\n0 1\n2 0\n"))
+	c.Check(err, check.Equals, NotSymmetric)
+}
+
+func (s *S) TestReadPajekLongLine(c *check.C) {
+	const n = 20000
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "*Vertices %d\n*Edgeslist\n1", n)
+	for i := 2; i <= n; i++ {
+		fmt.Fprintf(&buf, " %d", i)
+	}
+	buf.WriteByte('\n')
+	c.Assert(buf.Len() > bufio.MaxScanTokenSize, check.Equals, true)
+	g, err := ReadPajek(&buf)
+	c.Assert(err, check.Equals, nil)
+	c.Check(g.Size(), check.Equals, n-1)
+}
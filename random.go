@@ -0,0 +1,381 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// The random graph generators below create nodes with IDs in [0, n) and unweighted edges.
+// All random choices are made using the provided source so that graphs can be reproduced.
+
+// withOrder returns a new Undirected graph holding n unconnected nodes with IDs in [0, n).
+func withOrder(n int) *Undirected {
+	if n < 0 {
+		panic("graph: negative order")
+	}
+	g := NewUndirected()
+	for i := 0; i < n; i++ {
+		g.AddID(i)
+	}
+
+	return g
+}
+
+// GnP returns an Erdős–Rényi G(n, p) random graph with n nodes where each pair of distinct nodes
+// is joined by an edge independently with probability p. GnP panics if n is negative or p is
+// not in [0, 1].
+func GnP(n int, p float64, rnd *rand.Rand) *Undirected {
+	if p < 0 || p > 1 {
+		panic("graph: probability out of range")
+	}
+	g := withOrder(n)
+	g.connectWithin(0, n, p, rnd)
+
+	return g
+}
+
+// connectWithin joins each pair of distinct nodes with IDs in [off, off+n) independently with
+// probability p.
+func (g *Undirected) connectWithin(off, n int, p float64, rnd *rand.Rand) {
+	if p == 0 {
+		return
+	}
+	if p == 1 {
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				g.connectIDs(off+u, off+v, 1)
+			}
+		}
+		return
+	}
+
+	// Skip over absent edges using geometrically distributed gaps.
+	// See Batagelj and Brandes doi:10.1103/PhysRevE.71.036113.
+	lp := math.Log(1 - p)
+	for v, w := 1, -1; v < n; {
+		w += 1 + int(math.Log(1-rnd.Float64())/lp)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			g.connectIDs(off+w, off+v, 1)
+		}
+	}
+}
+
+// connectBetween joins each node with ID in [uoff, uoff+nu) to each node with ID in
+// [voff, voff+nv) independently with probability p.
+func (g *Undirected) connectBetween(uoff, nu, voff, nv int, p float64, rnd *rand.Rand) {
+	if p == 0 {
+		return
+	}
+	if p == 1 {
+		for u := 0; u < nu; u++ {
+			for v := 0; v < nv; v++ {
+				g.connectIDs(uoff+u, voff+v, 1)
+			}
+		}
+		return
+	}
+
+	// As for connectWithin, but over the nu×nv pairs in row-major order.
+	lp := math.Log(1 - p)
+	for i := -1; ; {
+		gap := math.Log(1-rnd.Float64()) / lp
+		if gap >= float64(nu*nv-1-i) {
+			break
+		}
+		i += 1 + int(gap)
+		g.connectIDs(uoff+i/nv, voff+i%nv, 1)
+	}
+}
+
+// GnM returns an Erdős–Rényi G(n, m) random graph with n nodes and m edges chosen uniformly
+// from the set of possible edges between distinct nodes. GnM panics if n or m is negative or
+// m is greater than n(n-1)/2.
+func GnM(n, m int, rnd *rand.Rand) *Undirected {
+	max := n * (n - 1) / 2
+	if m < 0 || m > max {
+		panic("graph: edge count out of range")
+	}
+	g := withOrder(n)
+
+	// Choose the smaller of the sets of present and absent edges.
+	complement := m > max/2
+	k := m
+	if complement {
+		k = max - m
+	}
+	chosen := make(map[[2]int]struct{}, k)
+	var pairs [][2]int
+	if !complement {
+		pairs = make([][2]int, 0, k)
+	}
+	for len(chosen) < k {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u == v {
+			continue
+		}
+		if u > v {
+			u, v = v, u
+		}
+		if _, ok := chosen[[2]int{u, v}]; ok {
+			continue
+		}
+		chosen[[2]int{u, v}] = struct{}{}
+		if !complement {
+			pairs = append(pairs, [2]int{u, v})
+		}
+	}
+
+	if !complement {
+		for _, e := range pairs {
+			g.connectIDs(e[0], e[1], 1)
+		}
+		return g
+	}
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			if _, ok := chosen[[2]int{u, v}]; !ok {
+				g.connectIDs(u, v, 1)
+			}
+		}
+	}
+
+	return g
+}
+
+// BarabasiAlbert returns a Barabási–Albert preferential attachment random graph with n nodes.
+// Starting from m unconnected nodes, each new node is joined to m distinct existing nodes
+// chosen with probability proportional to their degree. BarabasiAlbert panics if m is not
+// in [1, n).
+func BarabasiAlbert(n, m int, rnd *rand.Rand) *Undirected {
+	if m < 1 || m >= n {
+		panic("graph: attachment count out of range")
+	}
+	g := withOrder(n)
+
+	// repeated holds each node once for each of its incident edges,
+	// so uniform choice from it is proportional to degree.
+	repeated := make([]int, 0, 2*m*(n-m))
+	targets := make([]int, m)
+	for i := range targets {
+		targets[i] = i
+	}
+	chosen := make(map[int]struct{}, m)
+	for u := m; u < n; u++ {
+		for _, v := range targets {
+			g.connectIDs(u, v, 1)
+			repeated = append(repeated, u, v)
+		}
+
+		for k := range chosen {
+			delete(chosen, k)
+		}
+		targets = targets[:0]
+		for len(targets) < m {
+			v := repeated[rnd.Intn(len(repeated))]
+			if _, ok := chosen[v]; ok {
+				continue
+			}
+			chosen[v] = struct{}{}
+			targets = append(targets, v)
+		}
+	}
+
+	return g
+}
+
+// WattsStrogatz returns a Watts–Strogatz small-world random graph with n nodes. Each node
+// is initially joined to its k nearest neighbors in a ring, k/2 on each side, and then each
+// edge is rewired with probability beta to join a uniformly chosen node, avoiding self-loops
+// and parallel edges. WattsStrogatz panics if k is odd, k is not in [0, n) or beta is not in
+// [0, 1].
+func WattsStrogatz(n, k int, beta float64, rnd *rand.Rand) *Undirected {
+	if k < 0 || k >= n || k%2 != 0 {
+		panic("graph: neighbor count out of range")
+	}
+	if beta < 0 || beta > 1 {
+		panic("graph: probability out of range")
+	}
+	g := withOrder(n)
+
+	adj := make([]map[int]struct{}, n)
+	for u := range adj {
+		adj[u] = make(map[int]struct{}, k)
+	}
+	join := func(u, v int) {
+		adj[u][v] = struct{}{}
+		adj[v][u] = struct{}{}
+	}
+	type pair struct{ u, v int }
+	var edges []pair
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			v := (u + j) % n
+			join(u, v)
+			edges = append(edges, pair{u, v})
+		}
+	}
+
+	for i, e := range edges {
+		if rnd.Float64() >= beta || len(adj[e.u]) >= n-1 {
+			continue
+		}
+		w := rnd.Intn(n)
+		for _, ok := adj[e.u][w]; w == e.u || ok; _, ok = adj[e.u][w] {
+			w = rnd.Intn(n)
+		}
+		delete(adj[e.u], e.v)
+		delete(adj[e.v], e.u)
+		join(e.u, w)
+		edges[i].v = w
+	}
+	for _, e := range edges {
+		g.connectIDs(e.u, e.v, 1)
+	}
+
+	return g
+}
+
+// RandomRegular returns a random d-regular graph with n nodes and no self-loops or parallel
+// edges. Node stubs are paired following the approach of Steger and Wormald
+// doi:10.1017/S0963548399003867, giving an asymptotically uniform distribution for small d.
+// RandomRegular panics if d is not in [0, n) or n*d is odd.
+func RandomRegular(n, d int, rnd *rand.Rand) *Undirected {
+	if d < 0 || d >= n || n*d%2 != 0 {
+		panic("graph: degree out of range")
+	}
+	for {
+		g, ok := tryRegular(n, d, rnd)
+		if ok {
+			return g
+		}
+	}
+}
+
+// tryRegular attempts to build a random d-regular graph by pairing node stubs, returning
+// false if the pairing reaches a state where no suitable pair remains.
+func tryRegular(n, d int, rnd *rand.Rand) (*Undirected, bool) {
+	edges := make(map[[2]int]struct{}, n*d/2)
+	has := func(u, v int) bool {
+		if u > v {
+			u, v = v, u
+		}
+		_, ok := edges[[2]int{u, v}]
+		return ok
+	}
+
+	stubs := make([]int, 0, n*d)
+	for u := 0; u < n; u++ {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, u)
+		}
+	}
+	for len(stubs) > 0 {
+		potential := make(map[int]int)
+		rnd.Shuffle(len(stubs), func(i, j int) { stubs[i], stubs[j] = stubs[j], stubs[i] })
+		for i := 0; i+1 < len(stubs); i += 2 {
+			u, v := stubs[i], stubs[i+1]
+			if u > v {
+				u, v = v, u
+			}
+			if u != v && !has(u, v) {
+				edges[[2]int{u, v}] = struct{}{}
+			} else {
+				potential[u]++
+				potential[v]++
+			}
+		}
+		if !suitable(edges, potential) {
+			return nil, false
+		}
+		stubs = stubs[:0]
+		for u, c := range potential {
+			for i := 0; i < c; i++ {
+				stubs = append(stubs, u)
+			}
+		}
+		sort.Ints(stubs)
+	}
+
+	g := withOrder(n)
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			if has(u, v) {
+				g.connectIDs(u, v, 1)
+			}
+		}
+	}
+
+	return g, true
+}
+
+// suitable returns whether a pair of remaining stubs in potential can still be joined
+// without creating a self-loop or parallel edge.
+func suitable(edges map[[2]int]struct{}, potential map[int]int) bool {
+	if len(potential) == 0 {
+		return true
+	}
+	for u := range potential {
+		for v := range potential {
+			if u >= v {
+				continue
+			}
+			if _, ok := edges[[2]int{u, v}]; !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// StochasticBlock returns a stochastic block model random graph. The graph has one block of
+// nodes for each element of sizes, with node IDs assigned consecutively by block, and each pair
+// of distinct nodes in blocks a and b is joined by an edge independently with probability p[a][b].
+// StochasticBlock panics if any size is negative or p is not a symmetric len(sizes)×len(sizes)
+// matrix of probabilities.
+func StochasticBlock(sizes []int, p [][]float64, rnd *rand.Rand) *Undirected {
+	if len(p) != len(sizes) {
+		panic("graph: probability matrix dimension mismatch")
+	}
+	var n int
+	for b, s := range sizes {
+		if s < 0 {
+			panic("graph: negative block size")
+		}
+		if len(p[b]) != len(sizes) {
+			panic("graph: probability matrix dimension mismatch")
+		}
+		for a := range p[b] {
+			if p[b][a] < 0 || p[b][a] > 1 {
+				panic("graph: probability out of range")
+			}
+			if p[b][a] != p[a][b] {
+				panic("graph: probability matrix not symmetric")
+			}
+		}
+		n += s
+	}
+
+	// off[b] is the ID of the first node in block b.
+	off := make([]int, len(sizes))
+	for b := 1; b < len(sizes); b++ {
+		off[b] = off[b-1] + sizes[b-1]
+	}
+	g := withOrder(n)
+	for a := range sizes {
+		g.connectWithin(off[a], sizes[a], p[a][a], rnd)
+		for b := a + 1; b < len(sizes); b++ {
+			g.connectBetween(off[a], sizes[a], off[b], sizes[b], p[a][b], rnd)
+		}
+	}
+
+	return g
+}
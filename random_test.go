@@ -0,0 +1,130 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math/rand"
+
+	"gopkg.in/check.v1"
+)
+
+// simple returns whether g has no self-loops or parallel edges.
+func simple(g *Undirected) bool {
+	seen := make(map[[2]int]bool)
+	for _, e := range g.Edges() {
+		u, v := e.Tail().ID(), e.Head().ID()
+		if u == v {
+			return false
+		}
+		if u > v {
+			u, v = v, u
+		}
+		if seen[[2]int{u, v}] {
+			return false
+		}
+		seen[[2]int{u, v}] = true
+	}
+	return true
+}
+
+func (s *S) TestGnP(c *check.C) {
+	for _, p := range []float64{0, 0.1, 0.5, 1} {
+		g := GnP(100, p, rand.New(rand.NewSource(1)))
+		c.Check(g.Order(), check.Equals, 100)
+		c.Check(simple(g), check.Equals, true)
+		exp := p * 100 * 99 / 2
+		c.Check(float64(g.Size()) >= 0.8*exp && float64(g.Size()) <= 1.2*exp, check.Equals, true,
+			check.Commentf("p=%v size=%d", p, g.Size()))
+	}
+	a, _ := AdjacencyMatrix(GnP(50, 0.2, rand.New(rand.NewSource(2))))
+	b, _ := AdjacencyMatrix(GnP(50, 0.2, rand.New(rand.NewSource(2))))
+	c.Check(a, check.DeepEquals, b)
+}
+
+func (s *S) TestGnM(c *check.C) {
+	for _, m := range []int{0, 10, 1000, 4950} {
+		g := GnM(100, m, rand.New(rand.NewSource(1)))
+		c.Check(g.Order(), check.Equals, 100)
+		c.Check(g.Size(), check.Equals, m)
+		c.Check(simple(g), check.Equals, true)
+	}
+	for _, m := range []int{10, 4000} {
+		a, _ := AdjacencyMatrix(GnM(100, m, rand.New(rand.NewSource(2))))
+		b, _ := AdjacencyMatrix(GnM(100, m, rand.New(rand.NewSource(2))))
+		c.Check(a, check.DeepEquals, b)
+	}
+	g := GnM(20000, 100, rand.New(rand.NewSource(1)))
+	c.Check(g.Size(), check.Equals, 100)
+	c.Check(simple(g), check.Equals, true)
+}
+
+func (s *S) TestBarabasiAlbert(c *check.C) {
+	const n, m = 200, 3
+	g := BarabasiAlbert(n, m, rand.New(rand.NewSource(1)))
+	c.Check(g.Order(), check.Equals, n)
+	c.Check(g.Size(), check.Equals, m*(n-m))
+	c.Check(simple(g), check.Equals, true)
+	for _, u := range g.Nodes() {
+		c.Check(u.Degree() >= m, check.Equals, true)
+	}
+}
+
+func (s *S) TestWattsStrogatz(c *check.C) {
+	for _, beta := range []float64{0, 0.2, 1} {
+		g := WattsStrogatz(100, 6, beta, rand.New(rand.NewSource(1)))
+		c.Check(g.Order(), check.Equals, 100)
+		c.Check(g.Size(), check.Equals, 300)
+		c.Check(simple(g), check.Equals, true)
+		if beta == 0 {
+			for _, u := range g.Nodes() {
+				c.Check(u.Degree(), check.Equals, 6)
+			}
+		}
+	}
+}
+
+func (s *S) TestRandomRegular(c *check.C) {
+	for _, d := range []int{0, 3, 4, 9} {
+		g := RandomRegular(10, d, rand.New(rand.NewSource(1)))
+		c.Check(g.Order(), check.Equals, 10)
+		c.Check(simple(g), check.Equals, true)
+		for _, u := range g.Nodes() {
+			c.Check(u.Degree(), check.Equals, d)
+		}
+	}
+	c.Check(func() { RandomRegular(5, 3, rand.New(rand.NewSource(1))) }, check.Panics, "graph: degree out of range")
+}
+
+func (s *S) TestStochasticBlock(c *check.C) {
+	g := StochasticBlock([]int{20, 30}, [][]float64{{1, 0}, {0, 1}}, rand.New(rand.NewSource(1)))
+	c.Check(g.Order(), check.Equals, 50)
+	c.Check(g.Size(), check.Equals, 20*19/2+30*29/2)
+	c.Check(len(ConnectedComponents(g, nil)), check.Equals, 2)
+
+	block := func(id int) int {
+		if id < 30 {
+			return 0
+		}
+		return 1
+	}
+	for _, test := range []struct {
+		p     [][]float64
+		cross bool
+	}{
+		{p: [][]float64{{0, 0.5}, {0.5, 0}}, cross: true},
+		{p: [][]float64{{0.3, 0}, {0, 0.3}}, cross: false},
+	} {
+		g = StochasticBlock([]int{30, 40}, test.p, rand.New(rand.NewSource(1)))
+		c.Check(g.Size() > 0, check.Equals, true)
+		c.Check(simple(g), check.Equals, true)
+		for _, e := range g.Edges() {
+			c.Check(block(e.Tail().ID()) != block(e.Head().ID()), check.Equals, test.cross)
+		}
+	}
+
+	g = StochasticBlock([]int{10000, 10000}, [][]float64{{1e-5, 1e-6}, {1e-6, 1e-5}}, rand.New(rand.NewSource(1)))
+	c.Check(g.Order(), check.Equals, 20000)
+	c.Check(simple(g), check.Equals, true)
+}
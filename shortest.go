@@ -0,0 +1,162 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// A pathTree holds the shortest path directed acyclic graph from a single source node. Slices
+// are indexed by node ID.
+type pathTree struct {
+	weighted bool
+
+	// order holds the nodes reachable from the source in non-decreasing
+	// order of distance.
+	order []Node
+
+	dist  []float64
+	sigma []float64
+	pred  [][]Hop
+
+	q  *queue
+	pq distQueue
+}
+
+// newPathTree returns a pathTree for the graph g. If weighted is true, edge weights are used
+// as path lengths and must be positive, otherwise each edge has length 1. newPathTree panics
+// if weighted is true and any edge of g has a weight that is not positive.
+func newPathTree(g *Undirected, weighted bool) *pathTree {
+	checkLengths(g, weighted)
+	n := g.NextNodeID()
+	t := &pathTree{
+		weighted: weighted,
+		dist:     make([]float64, n),
+		sigma:    make([]float64, n),
+		pred:     make([][]Hop, n),
+		q:        &queue{},
+	}
+	for i := range t.dist {
+		t.dist[i] = math.Inf(1)
+	}
+
+	return t
+}
+
+// checkLengths panics if weighted is true and any edge of g has a weight that is not positive,
+// including NaN weights.
+func checkLengths(g *Undirected, weighted bool) {
+	if !weighted {
+		return
+	}
+	for _, e := range g.Edges() {
+		if !(e.Weight() > 0) {
+			panic("graph: non-positive edge weight")
+		}
+	}
+}
+
+// from finds the shortest paths from s, counting the number of shortest paths to each node
+// and recording the hops that lead to each node from its predecessors on those paths.
+// Parallel edges are counted as distinct paths and self-loops are ignored.
+func (t *pathTree) from(s Node) {
+	for _, n := range t.order {
+		id := n.ID()
+		t.dist[id] = math.Inf(1)
+		t.sigma[id] = 0
+		t.pred[id] = t.pred[id][:0]
+	}
+	t.order = t.order[:0]
+
+	id := s.ID()
+	t.dist[id] = 0
+	t.sigma[id] = 1
+	if t.weighted {
+		t.dijkstra(s)
+	} else {
+		t.bfs(s)
+	}
+}
+
+func (t *pathTree) bfs(s Node) {
+	t.q.Enqueue(s)
+	for t.q.Len() > 0 {
+		u, err := t.q.Dequeue()
+		if err != nil {
+			panic(err)
+		}
+		t.order = append(t.order, u)
+		uid := u.ID()
+		for _, h := range u.Hops(nil) {
+			vid := h.Node.ID()
+			if vid == uid {
+				continue
+			}
+			if math.IsInf(t.dist[vid], 1) {
+				t.dist[vid] = t.dist[uid] + 1
+				t.q.Enqueue(h.Node)
+			}
+			if t.dist[vid] == t.dist[uid]+1 {
+				t.sigma[vid] += t.sigma[uid]
+				t.pred[vid] = append(t.pred[vid], Hop{Edge: h.Edge, Node: u})
+			}
+		}
+	}
+}
+
+func (t *pathTree) dijkstra(s Node) {
+	heap.Push(&t.pq, distNode{s, 0})
+	for t.pq.Len() > 0 {
+		dn := heap.Pop(&t.pq).(distNode)
+		u := dn.n
+		uid := u.ID()
+		if dn.d > t.dist[uid] {
+			continue
+		}
+		t.order = append(t.order, u)
+		for _, h := range u.Hops(nil) {
+			vid := h.Node.ID()
+			if vid == uid {
+				continue
+			}
+			d := t.dist[uid] + h.Edge.Weight()
+			switch {
+			case d < t.dist[vid]:
+				t.dist[vid] = d
+				t.sigma[vid] = t.sigma[uid]
+				t.pred[vid] = append(t.pred[vid][:0], Hop{Edge: h.Edge, Node: u})
+				heap.Push(&t.pq, distNode{h.Node, d})
+			case d == t.dist[vid]:
+				t.sigma[vid] += t.sigma[uid]
+				t.pred[vid] = append(t.pred[vid], Hop{Edge: h.Edge, Node: u})
+			}
+		}
+	}
+}
+
+// reached returns whether n was reached in the last call to from.
+func (t *pathTree) reached(n Node) bool {
+	return !math.IsInf(t.dist[n.ID()], 1)
+}
+
+type distNode struct {
+	n Node
+	d float64
+}
+
+// distQueue is a min-priority queue of nodes ordered by distance.
+type distQueue []distNode
+
+func (q distQueue) Len() int            { return len(q) }
+func (q distQueue) Less(i, j int) bool  { return q[i].d < q[j].d }
+func (q distQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distQueue) Push(x interface{}) { *q = append(*q, x.(distNode)) }
+func (q *distQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,218 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+// The structured graph generators below create nodes with IDs in [0, n) and unweighted edges.
+// Edges are created in a fixed order, so edge IDs are also predictable.
+
+// Complete returns the complete graph on n nodes. Complete panics if n is negative.
+func Complete(n int) *Undirected {
+	g := withOrder(n)
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			g.connectIDs(u, v, 1)
+		}
+	}
+
+	return g
+}
+
+// CompleteBipartite returns the complete bipartite graph with parts of m and n nodes. The
+// first part holds nodes with IDs in [0, m) and the second part nodes with IDs in [m, m+n).
+// CompleteBipartite panics if m or n is negative.
+func CompleteBipartite(m, n int) *Undirected {
+	if m < 0 || n < 0 {
+		panic("graph: negative order")
+	}
+	g := withOrder(m + n)
+	for u := 0; u < m; u++ {
+		for v := m; v < m+n; v++ {
+			g.connectIDs(u, v, 1)
+		}
+	}
+
+	return g
+}
+
+// Path returns the path graph on n nodes, joining node i to node i+1. Path panics if n is
+// negative.
+func Path(n int) *Undirected {
+	g := withOrder(n)
+	for u := 1; u < n; u++ {
+		g.connectIDs(u-1, u, 1)
+	}
+
+	return g
+}
+
+// Cycle returns the cycle graph on n nodes, joining node i to node (i+1)%n. Cycle panics if
+// n is less than 3.
+func Cycle(n int) *Undirected {
+	if n < 3 {
+		panic("graph: cycle order out of range")
+	}
+	g := Path(n)
+	g.connectIDs(n-1, 0, 1)
+
+	return g
+}
+
+// Star returns the star graph with a central node with ID 0 joined to n leaves with IDs in
+// [1, n]. Star panics if n is negative.
+func Star(n int) *Undirected {
+	g := withOrder(n + 1)
+	for v := 1; v <= n; v++ {
+		g.connectIDs(0, v, 1)
+	}
+
+	return g
+}
+
+// Wheel returns the wheel graph on n nodes, a hub with ID 0 joined to each node of a cycle of
+// the nodes with IDs in [1, n). Wheel panics if n is less than 4.
+func Wheel(n int) *Undirected {
+	if n < 4 {
+		panic("graph: wheel order out of range")
+	}
+	g := Star(n - 1)
+	for u := 2; u < n; u++ {
+		g.connectIDs(u-1, u, 1)
+	}
+	g.connectIDs(n-1, 1, 1)
+
+	return g
+}
+
+// Grid returns a lattice graph with the given dimensions, for example a 2D grid for two
+// dimensions or a 3D lattice for three. Each node is joined to the nodes differing by one in
+// a single coordinate. If periodic is true the lattice wraps around in each dimension longer
+// than two, forming a torus. The node with coordinates (x₀, x₁, …, xₖ) has the row-major ID
+// ((x₀·d₁ + x₁)·d₂ + …)·dₖ + xₖ. Grid panics if any dimension is negative.
+func Grid(dims []int, periodic bool) *Undirected {
+	n := 1
+	for _, d := range dims {
+		if d < 0 {
+			panic("graph: negative dimension")
+		}
+		n *= d
+	}
+	if len(dims) == 0 {
+		n = 0
+	}
+	g := withOrder(n)
+
+	coord := make([]int, len(dims))
+	for u := 0; u < n; u++ {
+		for i, stride := len(dims)-1, 1; i >= 0; i-- {
+			switch {
+			case coord[i]+1 < dims[i]:
+				g.connectIDs(u, u+stride, 1)
+			case periodic && dims[i] > 2:
+				g.connectIDs(u, u-coord[i]*stride, 1)
+			}
+			stride *= dims[i]
+		}
+
+		// Advance the coordinate odometer.
+		for i := len(dims) - 1; i >= 0; i-- {
+			coord[i]++
+			if coord[i] < dims[i] {
+				break
+			}
+			coord[i] = 0
+		}
+	}
+
+	return g
+}
+
+// Hypercube returns the d-dimensional hypercube graph with 2^d nodes, joining nodes whose IDs
+// differ in exactly one bit. Hypercube panics if d is negative.
+func Hypercube(d int) *Undirected {
+	if d < 0 {
+		panic("graph: negative dimension")
+	}
+	g := withOrder(1 << uint(d))
+	for u := 0; u < g.Order(); u++ {
+		for b := 0; b < d; b++ {
+			if v := u ^ 1<<uint(b); u < v {
+				g.connectIDs(u, v, 1)
+			}
+		}
+	}
+
+	return g
+}
+
+// Petersen returns the Petersen graph. Nodes with IDs in [0, 5) form the outer cycle and nodes
+// with IDs in [5, 10) form the inner pentagram, with node i joined to node i+5.
+func Petersen() *Undirected {
+	g := withOrder(10)
+	for i := 0; i < 5; i++ {
+		g.connectIDs(i, (i+1)%5, 1)
+	}
+	for i := 0; i < 5; i++ {
+		g.connectIDs(i, i+5, 1)
+	}
+	for i := 0; i < 5; i++ {
+		g.connectIDs(i+5, (i+2)%5+5, 1)
+	}
+
+	return g
+}
+
+// BalancedTree returns the perfectly balanced r-ary tree of height h. The root has ID 0 and the
+// children of node i have IDs in [r·i+1, r·i+r]. BalancedTree panics if r is less than 1 or h is
+// negative.
+func BalancedTree(r, h int) *Undirected {
+	if r < 1 {
+		panic("graph: branching factor out of range")
+	}
+	if h < 0 {
+		panic("graph: negative height")
+	}
+	n, level := 1, 1
+	for i := 0; i < h; i++ {
+		level *= r
+		n += level
+	}
+	g := withOrder(n)
+	for v := 1; v < n; v++ {
+		g.connectIDs((v-1)/r, v, 1)
+	}
+
+	return g
+}
+
+// BinaryTree returns the perfectly balanced binary tree of height h as described by BalancedTree.
+func BinaryTree(h int) *Undirected {
+	return BalancedTree(2, h)
+}
+
+// Barbell returns the barbell graph formed by two complete graphs of m nodes joined by a path of
+// p nodes. The complete graphs hold the nodes with IDs in [0, m) and [m+p, 2m+p), and the path
+// runs from node m-1 through the nodes with IDs in [m, m+p) to node m+p. Barbell panics if m is
+// less than 2 or p is negative.
+func Barbell(m, p int) *Undirected {
+	if m < 2 {
+		panic("graph: barbell bell order out of range")
+	}
+	if p < 0 {
+		panic("graph: negative path order")
+	}
+	g := withOrder(2*m + p)
+	for _, off := range [...]int{0, m + p} {
+		for u := off; u < off+m; u++ {
+			for v := u + 1; v < off+m; v++ {
+				g.connectIDs(u, v, 1)
+			}
+		}
+	}
+	for u := m; u <= m+p; u++ {
+		g.connectIDs(u-1, u, 1)
+	}
+
+	return g
+}
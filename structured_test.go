@@ -0,0 +1,56 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestStructured(c *check.C) {
+	for i, t := range []struct {
+		g      *Undirected
+		order  int
+		size   int
+		degree []int
+	}{
+		{g: Complete(6), order: 6, size: 15, degree: []int{5}},
+		{g: CompleteBipartite(3, 4), order: 7, size: 12, degree: []int{3, 4}},
+		{g: Path(5), order: 5, size: 4, degree: []int{1, 2}},
+		{g: Cycle(5), order: 5, size: 5, degree: []int{2}},
+		{g: Star(5), order: 6, size: 5, degree: []int{1, 5}},
+		{g: Wheel(6), order: 6, size: 10, degree: []int{3, 5}},
+		{g: Grid([]int{3, 4}, false), order: 12, size: 17, degree: []int{2, 3, 4}},
+		{g: Grid([]int{3, 4}, true), order: 12, size: 24, degree: []int{4}},
+		{g: Grid([]int{2, 3, 4}, false), order: 24, size: 46, degree: []int{3, 4, 5}},
+		{g: Grid([]int{3, 3, 3}, true), order: 27, size: 81, degree: []int{6}},
+		{g: Hypercube(4), order: 16, size: 32, degree: []int{4}},
+		{g: Petersen(), order: 10, size: 15, degree: []int{3}},
+		{g: BinaryTree(3), order: 15, size: 14, degree: []int{1, 2, 3}},
+		{g: BalancedTree(3, 2), order: 13, size: 12, degree: []int{1, 3, 4}},
+		{g: Barbell(4, 2), order: 10, size: 15, degree: []int{2, 3, 4}},
+	} {
+		c.Check(t.g.Order(), check.Equals, t.order, check.Commentf("test %d", i))
+		c.Check(t.g.Size(), check.Equals, t.size, check.Commentf("test %d", i))
+		c.Check(simple(t.g), check.Equals, true, check.Commentf("test %d", i))
+		c.Check(len(ConnectedComponents(t.g, nil)), check.Equals, 1, check.Commentf("test %d", i))
+		degrees := make(map[int]bool)
+		for _, n := range t.g.Nodes() {
+			degrees[n.Degree()] = true
+		}
+		for _, d := range t.degree {
+			c.Check(degrees[d], check.Equals, true, check.Commentf("test %d degree %d", i, d))
+			delete(degrees, d)
+		}
+		c.Check(degrees, check.HasLen, 0, check.Commentf("test %d", i))
+	}
+}
+
+func (s *S) TestGridIDs(c *check.C) {
+	g := Grid([]int{3, 4}, false)
+	ok, _ := g.Connected(g.Node(0), g.Node(4))
+	c.Check(ok, check.Equals, true)
+	ok, _ = g.Connected(g.Node(3), g.Node(4))
+	c.Check(ok, check.Equals, false)
+}
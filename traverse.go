@@ -4,14 +4,28 @@
 
 package graph
 
+import (
+	"context"
+)
+
 // Visit is a function type that is used by a BreadthFirst or DepthFirst to allow side-effects
 // on visiting new nodes in a graph traversal.
 type Visit func(u, v Node)
 
+// HopVisit is a function type that is used by a BreadthFirst to allow side-effects on visiting
+// new nodes in a graph traversal. It is called with the node u from which the hop h is made and
+// the depth of h.Node from the start of the traversal.
+type HopVisit func(u Node, h Hop, depth int)
+
 // BreadthFirst is a type that can perform a breadth-first search on a graph.
 type BreadthFirst struct {
-	q      *queue
-	visits []bool
+	q       *queue
+	visits  []bool
+	parents []Hop
+
+	// depths holds the depth of each visited node
+	// from the node its search started from.
+	depths []int
 }
 
 // NewBreadthFirst creates a new BreadthFirst searcher.
@@ -24,28 +38,75 @@ func NewBreadthFirst() *BreadthFirst {
 // the terminating node, t is returned. If vo is not nil, it is called with the start and end nodes of an
 // edge when the end node has not already been visited.
 func (b *BreadthFirst) Search(s Node, ef EdgeFilter, nf NodeFilter, vo Visit) Node {
-	b.q.Enqueue(s)
-	b.visits = mark(s, b.visits)
-	for b.q.Len() > 0 {
+	return b.Walk(s, ef, nf, visitHops(vo), -1)
+}
+
+// visitHops returns a HopVisit that calls vo, or nil if vo is nil.
+func visitHops(vo Visit) HopVisit {
+	if vo == nil {
+		return nil
+	}
+	return func(u Node, h Hop, _ int) { vo(u, h.Node) }
+}
+
+// SearchContext performs Search, checking periodically whether ctx is done. If ctx is done, the
+// search is stopped before the next node is taken from the queue and nil is returned with the
+// error ctx.Err(). The search may be continued from where it stopped by calling SearchContext or
+// Search again with the same arguments; as s has already been visited, it is not queued again.
+func (b *BreadthFirst) SearchContext(ctx context.Context, s Node, ef EdgeFilter, nf NodeFilter, vo Visit) (Node, error) {
+	return b.walk(ctx, s, ef, nf, visitHops(vo), -1)
+}
+
+// Walk performs a breadth-first search of a graph starting from node s in the same way as Search,
+// expanding the graph one depth at a time. If vo is not nil, it is called with the node expanded,
+// the hop taken and the depth of the hop's node when the hop's node has not already been visited;
+// s has depth 0. Nodes at depth maxDepth are visited but not expanded. If maxDepth is negative,
+// the depth of the search is not limited. If s has already been visited, it is not queued again
+// and the search continues from the nodes remaining in the queue, so a stopped search may be
+// resumed by calling Walk again with the same arguments.
+func (b *BreadthFirst) Walk(s Node, ef EdgeFilter, nf NodeFilter, vo HopVisit, maxDepth int) Node {
+	t, _ := b.walk(context.Background(), s, ef, nf, vo, maxDepth)
+	return t
+}
+
+// walk performs Walk, checking whether ctx is done every contextCheck nodes.
+func (b *BreadthFirst) walk(ctx context.Context, s Node, ef EdgeFilter, nf NodeFilter, vo HopVisit, maxDepth int) (Node, error) {
+	if !b.Visited(s) {
+		b.q.Enqueue(s)
+		b.visits = mark(s, b.visits)
+		b.parents = setParent(s, Hop{}, b.parents)
+		b.depths = setInt(s.ID(), 0, b.depths)
+	}
+
+	for n := 1; b.q.Len() > 0; n++ {
+		if err := checkContext(ctx, n); err != nil {
+			return nil, err
+		}
 		t, err := b.q.Dequeue()
 		if err != nil {
 			panic(err)
 		}
 		if nf != nil && nf(t) {
-			return t
+			return t, nil
 		}
-		for _, n := range t.Neighbors(ef) {
-			if !b.Visited(n) {
+		depth := getInt(t.ID(), b.depths)
+		if maxDepth >= 0 && depth >= maxDepth {
+			continue
+		}
+		for _, h := range t.Hops(ef) {
+			if !b.Visited(h.Node) {
 				if vo != nil {
-					vo(t, n)
+					vo(t, *h, depth+1)
 				}
-				b.visits = mark(n, b.visits)
-				b.q.Enqueue(n)
+				b.visits = mark(h.Node, b.visits)
+				b.parents = setParent(h.Node, Hop{Edge: h.Edge, Node: t}, b.parents)
+				b.depths = setInt(h.Node.ID(), depth+1, b.depths)
+				b.q.Enqueue(h.Node)
 			}
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
 // Visited returns whether the node n has been visited by the searcher.
@@ -57,16 +118,53 @@ func (b *BreadthFirst) Visited(n Node) bool {
 	return b.visits[id]
 }
 
-// Reset clears the search queue and visited list.
+// PathTo returns the path to n from the node that the search reaching n started from, following
+// the hops by which the searcher first reached each node. The path is returned in the format used
+// by BidirectionalSearch. If n has not been visited, PathTo returns nil.
+func (b *BreadthFirst) PathTo(n Node) []Hop {
+	if !b.Visited(n) {
+		return nil
+	}
+	return pathTo(n, b.parents)
+}
+
+// Reset clears the search queue, visited list, path records and depths.
 func (b *BreadthFirst) Reset() {
 	b.q.Clear()
 	b.visits = b.visits[:0]
+	b.parents = b.parents[:0]
+	b.depths = b.depths[:0]
 }
 
 // DepthFirst is a type that can perform a depth-first search on a graph.
 type DepthFirst struct {
-	s      *stack
-	visits []bool
+	s       *stack
+	visits  []bool
+	parents []Hop
+
+	// disc and fin hold the discovery and finish times
+	// of nodes reached by Walk, or -1.
+	time      int
+	disc, fin []int
+}
+
+// DFSEvents holds the functions called during a DepthFirst walk. Nil functions are not called.
+type DFSEvents struct {
+	// PreOrder and PostOrder are called with each node
+	// and its discovery or finish time when the node is
+	// discovered and when all of its descendants have
+	// been finished.
+	PreOrder, PostOrder func(n Node, time int)
+
+	// TreeEdge, BackEdge, ForwardEdge and CrossEdge are
+	// called with the node u being explored and the hop
+	// from u according to the classification of the
+	// hop's edge. Tree edges lead to undiscovered nodes,
+	// back edges lead to ancestors of u, including u
+	// itself, forward edges lead to finished descendants
+	// of u and cross edges lead to all other finished
+	// nodes.
+	TreeEdge, BackEdge, ForwardEdge, CrossEdge func(u Node, h Hop)
 }
 
 // NewDepthFirst creates a new DepthFirst searcher.
@@ -79,28 +177,135 @@ func NewDepthFirst() *DepthFirst {
 // the terminating node, t is returned. If vo is not nil, it is called with the start and end nodes of an
 // edge when the end node has not already been visited.
 func (d *DepthFirst) Search(s Node, ef EdgeFilter, nf NodeFilter, vo Visit) Node {
-	d.s.Push(s)
-	d.visits = mark(s, d.visits)
-	for d.s.Len() > 0 {
+	t, _ := d.search(context.Background(), s, ef, nf, vo)
+	return t
+}
+
+// search performs Search, checking whether ctx is done every contextCheck nodes.
+func (d *DepthFirst) search(ctx context.Context, s Node, ef EdgeFilter, nf NodeFilter, vo Visit) (Node, error) {
+	if !d.Visited(s) {
+		d.s.Push(s)
+		d.visits = mark(s, d.visits)
+		d.parents = setParent(s, Hop{}, d.parents)
+	}
+	for n := 1; d.s.Len() > 0; n++ {
+		if err := checkContext(ctx, n); err != nil {
+			return nil, err
+		}
 		t, err := d.s.Pop()
 		if err != nil {
 			panic(err)
 		}
 		if nf != nil && nf(t) {
-			return t
+			return t, nil
 		}
-		for _, n := range t.Neighbors(ef) {
+		for _, h := range t.Hops(ef) {
+			n := h.Node
 			if !d.Visited(n) {
 				if vo != nil {
 					vo(t, n)
 				}
 				d.visits = mark(n, d.visits)
+				d.parents = setParent(n, Hop{Edge: h.Edge, Node: t}, d.parents)
 				d.s.Push(n)
 			}
 		}
 	}
 
-	return nil
+	return nil, nil
+}
+
+// SearchContext performs Search, checking periodically whether ctx is done. If ctx is done, the
+// search is stopped before the next node is taken from the stack and nil is returned with the
+// error ctx.Err(). The search may be continued from where it stopped by calling SearchContext or
+// Search again with the same arguments; as s has already been visited, it is not pushed again.
+func (d *DepthFirst) SearchContext(ctx context.Context, s Node, ef EdgeFilter, nf NodeFilter, vo Visit) (Node, error) {
+	return d.search(ctx, s, ef, nf, vo)
+}
+
+// Walk performs a depth-first search of a graph starting from node s, traversing edges in the
+// graph that allow the EdgeFilter function ef to return true, and calling the functions in ev.
+// Unlike Search, nodes are visited in true depth-first order, each node being finished only when
+// all nodes reachable from it through undiscovered nodes have been finished. Nodes already
+// discovered by an earlier call to Walk are not walked again, and times continue from the
+// earlier call, so a depth-first forest can be built by calling Walk from each node of a graph.
+//
+// If directed is true, each edge is only traversed from its tail to its head and edges are
+// classified as in a directed graph. Otherwise edges are traversed in both directions, and each
+// edge is reported once: an undirected depth-first search has only tree and back edges, and the
+// tree edge to a node is not reported again as a back edge from it.
+func (d *DepthFirst) Walk(s Node, ef EdgeFilter, directed bool, ev DFSEvents) {
+	if d.Discovery(s) >= 0 {
+		return
+	}
+
+	type frame struct {
+		n    Node
+		hops []*Hop
+		via  Edge
+	}
+	var stack []frame
+	discover := func(n Node, from Hop) {
+		d.visits = mark(n, d.visits)
+		d.parents = setParent(n, from, d.parents)
+		d.disc = setInt(n.ID(), d.time, d.disc)
+		if ev.PreOrder != nil {
+			ev.PreOrder(n, d.time)
+		}
+		d.time++
+		stack = append(stack, frame{n: n, hops: n.Hops(ef), via: from.Edge})
+	}
+	call := func(f func(Node, Hop), u Node, h *Hop) {
+		if f != nil {
+			f(u, *h)
+		}
+	}
+
+	discover(s, Hop{})
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		if len(f.hops) == 0 {
+			d.fin = setInt(f.n.ID(), d.time, d.fin)
+			if ev.PostOrder != nil {
+				ev.PostOrder(f.n, d.time)
+			}
+			d.time++
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		u, h := f.n, f.hops[0]
+		f.hops = f.hops[1:]
+		if directed && h.Edge.Tail().ID() != u.ID() {
+			continue
+		}
+
+		v := h.Node
+		switch {
+		case d.Discovery(v) < 0:
+			call(ev.TreeEdge, u, h)
+			discover(v, Hop{Edge: h.Edge, Node: u})
+		case d.Finish(v) < 0:
+			if !directed && h.Edge == f.via {
+				continue
+			}
+			call(ev.BackEdge, u, h)
+		case directed && d.Discovery(u) < d.Discovery(v):
+			call(ev.ForwardEdge, u, h)
+		case directed:
+			call(ev.CrossEdge, u, h)
+		}
+	}
+}
+
+// Discovery returns the time at which n was discovered by Walk, or -1 if n has not been
+// discovered.
+func (d *DepthFirst) Discovery(n Node) int {
+	return getInt(n.ID(), d.disc)
+}
+
+// Finish returns the time at which n was finished by Walk, or -1 if n has not been finished.
+func (d *DepthFirst) Finish(n Node) int {
+	return getInt(n.ID(), d.fin)
 }
 
 // Visited returns whether the node n has been visited by the searcher.
@@ -112,10 +317,35 @@ func (d *DepthFirst) Visited(n Node) bool {
 	return d.visits[id]
 }
 
-// Reset clears the search stack and visited list.
+// PathTo returns the path to n from the node that the search or walk reaching n started from,
+// following the hops by which the searcher first reached each node. The path is returned in the
+// format used by BidirectionalSearch. If n has not been visited, PathTo returns nil.
+func (d *DepthFirst) PathTo(n Node) []Hop {
+	if !d.Visited(n) {
+		return nil
+	}
+	return pathTo(n, d.parents)
+}
+
+// Reset clears the search stack, visited list, path records and walk times.
 func (d *DepthFirst) Reset() {
 	d.s.Clear()
 	d.visits = d.visits[:0]
+	d.parents = d.parents[:0]
+	d.time = 0
+	d.disc = d.disc[:0]
+	d.fin = d.fin[:0]
+}
+
+// contextCheck is the number of nodes visited between checks of a context.
+const contextCheck = 1 << 10
+
+// checkContext returns ctx.Err() if n is a multiple of contextCheck.
+func checkContext(ctx context.Context, n int) error {
+	if n%contextCheck != 0 {
+		return nil
+	}
+	return ctx.Err()
 }
 
 func mark(n Node, v []bool) []bool {
@@ -133,3 +363,139 @@ func mark(n Node, v []bool) []bool {
 	}
 	return v
 }
+
+func setParent(n Node, h Hop, p []Hop) []Hop {
+	id := n.ID()
+	for len(p) <= id {
+		p = append(p, Hop{})
+	}
+	p[id] = h
+	return p
+}
+
+// pathTo returns the path to n following the hops in parents, indexed by node ID, until a hop
+// without a node is reached.
+func pathTo(n Node, parents []Hop) []Hop {
+	var p []Hop
+	for n != nil {
+		h := parents[n.ID()]
+		p = append(p, Hop{Edge: h.Edge, Node: n})
+		n = h.Node
+	}
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+	return p
+}
+
+func setInt(id, x int, v []int) []int {
+	for len(v) <= id {
+		v = append(v, -1)
+	}
+	v[id] = x
+	return v
+}
+
+func getInt(id int, v []int) int {
+	if id < 0 || id >= len(v) {
+		return -1
+	}
+	return v[id]
+}
+
+// BidirectionalSearch returns a shortest path by number of hops from s to t, found by breadth-first
+// searches from both s and t that meet in the middle. Only edges that allow the EdgeFilter function
+// ef to return true are traversed and, if nf is not nil, only nodes for which nf returns true are
+// passed through; s and t are not tested. The path is returned as a slice of hops starting with
+// a hop holding s and a nil Edge, each later hop holding the edge taken and the node reached, so
+// that the last hop holds t. If there is no path from s to t, BidirectionalSearch returns nil.
+func BidirectionalSearch(s, t Node, ef EdgeFilter, nf NodeFilter) []Hop {
+	if s.ID() == t.ID() {
+		return []Hop{{Node: s}}
+	}
+
+	// Each side records the hop by which each node was
+	// reached, the distance of each node from the side's
+	// start node and the current frontier.
+	type side struct {
+		parent   map[int]Hop
+		dist     map[int]int
+		frontier []Node
+	}
+	sides := [2]*side{
+		{parent: map[int]Hop{s.ID(): {}}, dist: map[int]int{s.ID(): 0}, frontier: []Node{s}},
+		{parent: map[int]Hop{t.ID(): {}}, dist: map[int]int{t.ID(): 0}, frontier: []Node{t}},
+	}
+
+	for len(sides[0].frontier) > 0 && len(sides[1].frontier) > 0 {
+		// Expand the smaller frontier by one layer.
+		i := 0
+		if len(sides[1].frontier) < len(sides[0].frontier) {
+			i = 1
+		}
+		this, other := sides[i], sides[1-i]
+
+		var (
+			next     []Node
+			best     = -1
+			from, to Node
+			via      Edge
+		)
+		for _, u := range this.frontier {
+			for _, h := range u.Hops(ef) {
+				v := h.Node
+				id := v.ID()
+				if d, ok := other.dist[id]; ok {
+					if l := this.dist[u.ID()] + 1 + d; best < 0 || l < best {
+						best, from, to, via = l, u, v, h.Edge
+					}
+				}
+				if _, ok := this.dist[id]; ok {
+					continue
+				}
+				if nf != nil && id != s.ID() && id != t.ID() && !nf(v) {
+					continue
+				}
+				this.parent[id] = Hop{Edge: h.Edge, Node: u}
+				this.dist[id] = this.dist[u.ID()] + 1
+				next = append(next, v)
+			}
+		}
+		this.frontier = next
+
+		if best >= 0 {
+			if i == 1 {
+				from, to = to, from
+			}
+			return joinPath(sides[0].parent, sides[1].parent, from, to, via)
+		}
+	}
+
+	return nil
+}
+
+// joinPath returns the path from the root of the parent tree fwd to u, followed by the edge e to
+// v and the path from v to the root of the parent tree rev.
+func joinPath(fwd, rev map[int]Hop, u, v Node, e Edge) []Hop {
+	var p []Hop
+	for n := u; n != nil; {
+		h := fwd[n.ID()]
+		p = append(p, Hop{Edge: h.Edge, Node: n})
+		n = h.Node
+	}
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+
+	p = append(p, Hop{Edge: e, Node: v})
+	for n := v; ; {
+		h := rev[n.ID()]
+		if h.Node == nil {
+			break
+		}
+		p = append(p, h)
+		n = h.Node
+	}
+
+	return p
+}
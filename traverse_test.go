@@ -0,0 +1,295 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"context"
+	"math/rand"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestBreadthFirstWalk(c *check.C) {
+	g := BinaryTree(4)
+	depth := map[int]int{0: 0}
+	tree := make(map[int]int)
+	bf := NewBreadthFirst()
+	t := bf.Walk(g.Node(0), nil, nil, func(u Node, h Hop, d int) {
+		c.Check(d, check.Equals, depth[u.ID()]+1)
+		depth[h.Node.ID()] = d
+		tree[h.Edge.ID()] = h.Node.ID()
+	}, 2)
+	c.Check(t, check.IsNil)
+	c.Check(len(depth), check.Equals, 7)
+	c.Check(len(tree), check.Equals, 6)
+	for id, d := range depth {
+		c.Check(d <= 2, check.Equals, true)
+		c.Check(bf.Visited(g.Node(id)), check.Equals, true)
+	}
+	c.Check(bf.Visited(g.Node(7)), check.Equals, false)
+
+	// Without a limit every node is reached with its distance from the start.
+	bf.Reset()
+	var n int
+	bf.Walk(g.Node(0), nil, nil, func(u Node, h Hop, d int) {
+		n++
+		c.Check(d, check.Equals, treeDepth(h.Node.ID()))
+	}, -1)
+	c.Check(n, check.Equals, g.Order()-1)
+
+	bf.Reset()
+	t = bf.Walk(g.Node(0), nil, func(n Node) bool { return n.ID() == 5 }, nil, 1)
+	c.Check(t, check.IsNil)
+	bf.Reset()
+	t = bf.Walk(g.Node(0), nil, func(n Node) bool { return n.ID() == 5 }, nil, 2)
+	c.Check(t.ID(), check.Equals, 5)
+
+	// A stopped walk resumes with the depths of the queued nodes.
+	g = BinaryTree(13)
+	bf.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	n = 0
+	visit := func(u Node, h Hop, d int) {
+		n++
+		c.Check(d, check.Equals, treeDepth(h.Node.ID()))
+		if n == contextCheck {
+			cancel()
+		}
+	}
+	t, err := bf.walk(ctx, g.Node(0), nil, nil, visit, 11)
+	c.Check(err, check.Equals, context.Canceled)
+	c.Check(t, check.IsNil)
+	c.Check(n < 1<<12-2, check.Equals, true)
+	bf.Walk(g.Node(0), nil, nil, visit, 11)
+	c.Check(n, check.Equals, 1<<12-2)
+	c.Check(bf.Visited(g.Node(1<<12-1)), check.Equals, false)
+	checkPath(c, bf.PathTo(g.Node(1<<12-2)), 0, 1<<12-2)
+}
+
+// treeDepth returns the depth of a node of a BinaryTree.
+func treeDepth(id int) int {
+	var d int
+	for ; id > 0; id = (id - 1) / 2 {
+		d++
+	}
+	return d
+}
+
+func (s *S) TestBreadthFirstSearch(c *check.C) {
+	g := Cycle(6)
+	var visits [][2]int
+	bf := NewBreadthFirst()
+	bf.Search(g.Node(0), nil, nil, func(u, v Node) {
+		visits = append(visits, [2]int{u.ID(), v.ID()})
+	})
+	c.Check(len(visits), check.Equals, 5)
+	c.Check(visits[len(visits)-1][1], check.Equals, 3)
+}
+
+func (s *S) TestDepthFirstWalkDirected(c *check.C) {
+	g := NewUndirected()
+	for i := 0; i < 4; i++ {
+		g.AddID(i)
+	}
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {0, 2}, {3, 1}} {
+		g.ConnectByID(e[0], e[1])
+	}
+
+	var pre, post []int
+	kinds := make(map[int]string)
+	kind := func(k string) func(Node, Hop) {
+		return func(_ Node, h Hop) { kinds[h.Edge.ID()] = k }
+	}
+	ev := DFSEvents{
+		PreOrder:    func(n Node, _ int) { pre = append(pre, n.ID()) },
+		PostOrder:   func(n Node, _ int) { post = append(post, n.ID()) },
+		TreeEdge:    kind("tree"),
+		BackEdge:    kind("back"),
+		ForwardEdge: kind("forward"),
+		CrossEdge:   kind("cross"),
+	}
+	df := NewDepthFirst()
+	for _, n := range []int{0, 3, 1} {
+		df.Walk(g.Node(n), nil, true, ev)
+	}
+	c.Check(pre, check.DeepEquals, []int{0, 1, 2, 3})
+	c.Check(post, check.DeepEquals, []int{2, 1, 0, 3})
+	c.Check(kinds, check.DeepEquals, map[int]string{0: "tree", 1: "tree", 2: "back", 3: "forward", 4: "cross"})
+	for n, t := range map[int][2]int{0: {0, 5}, 1: {1, 4}, 2: {2, 3}, 3: {6, 7}} {
+		c.Check(df.Discovery(g.Node(n)), check.Equals, t[0])
+		c.Check(df.Finish(g.Node(n)), check.Equals, t[1])
+	}
+
+	df.Reset()
+	c.Check(df.Discovery(g.Node(0)), check.Equals, -1)
+	c.Check(df.Visited(g.Node(0)), check.Equals, false)
+}
+
+func (s *S) TestDepthFirstWalkUndirected(c *check.C) {
+	// A cycle with a parallel edge, a self-loop and
+	// a pendant node.
+	g := Cycle(4)
+	g.AddID(4)
+	g.ConnectByID(0, 1)
+	g.ConnectByID(2, 2)
+	g.ConnectByID(3, 4)
+
+	var tree, back int
+	seen := make(map[int]bool)
+	df := NewDepthFirst()
+	df.Walk(g.Node(0), nil, false, DFSEvents{
+		PostOrder: func(n Node, _ int) {
+			for _, h := range n.Hops(nil) {
+				// Descendants are finished before their ancestors.
+				if df.Discovery(h.Node) > df.Discovery(n) && df.Finish(h.Node) >= 0 {
+					c.Check(df.Finish(h.Node) < df.Finish(n), check.Equals, true)
+				}
+			}
+		},
+		TreeEdge: func(_ Node, h Hop) {
+			tree++
+			c.Check(seen[h.Edge.ID()], check.Equals, false)
+			seen[h.Edge.ID()] = true
+		},
+		BackEdge: func(u Node, h Hop) {
+			back++
+			c.Check(seen[h.Edge.ID()], check.Equals, false)
+			seen[h.Edge.ID()] = true
+			c.Check(df.Discovery(h.Node) <= df.Discovery(u), check.Equals, true)
+		},
+		ForwardEdge: func(Node, Hop) { c.Error("unexpected forward edge") },
+		CrossEdge:   func(Node, Hop) { c.Error("unexpected cross edge") },
+	})
+	c.Check(tree, check.Equals, g.Order()-1)
+	c.Check(back, check.Equals, g.Size()-tree)
+}
+
+// checkPath checks that p is a valid path in g from s to t.
+func checkPath(c *check.C, p []Hop, s, t int) {
+	c.Assert(len(p) > 0, check.Equals, true)
+	c.Check(p[0].Edge, check.IsNil)
+	c.Check(p[0].Node.ID(), check.Equals, s)
+	c.Check(p[len(p)-1].Node.ID(), check.Equals, t)
+	for i, h := range p[1:] {
+		u, v := h.Edge.Nodes()
+		ends := [2]int{u.ID(), v.ID()}
+		c.Check(ends == [2]int{p[i].Node.ID(), h.Node.ID()} || ends == [2]int{h.Node.ID(), p[i].Node.ID()}, check.Equals, true)
+	}
+}
+
+func (s *S) TestBidirectionalSearch(c *check.C) {
+	g := GnP(60, 0.05, rand.New(rand.NewSource(1)))
+	pt := newPathTree(g, false)
+	for _, u := range g.Nodes() {
+		pt.from(u)
+		for _, v := range g.Nodes() {
+			p := BidirectionalSearch(u, v, nil, nil)
+			if !pt.reached(v) {
+				c.Check(p, check.IsNil)
+				continue
+			}
+			checkPath(c, p, u.ID(), v.ID())
+			c.Check(float64(len(p)-1), check.Equals, pt.dist[v.ID()])
+		}
+	}
+
+	cy := Cycle(6)
+	p := BidirectionalSearch(cy.Node(0), cy.Node(2), nil, func(n Node) bool { return n.ID() != 1 })
+	checkPath(c, p, 0, 2)
+	c.Check(len(p), check.Equals, 5)
+	p = BidirectionalSearch(cy.Node(0), cy.Node(3), nil, func(n Node) bool { return n.ID()%3 != 1 && n.ID() != 5 })
+	c.Check(p, check.IsNil)
+	p = BidirectionalSearch(cy.Node(0), cy.Node(1), func(e Edge) bool { return e.ID() != 0 }, nil)
+	checkPath(c, p, 0, 1)
+	c.Check(len(p), check.Equals, 6)
+}
+
+func (s *S) TestPathTo(c *check.C) {
+	g := Grid([]int{4, 5}, false)
+	g.AddID(20)
+	pt := newPathTree(g, false)
+	pt.from(g.Node(0))
+
+	bf := NewBreadthFirst()
+	bf.Search(g.Node(0), nil, nil, nil)
+	df := NewDepthFirst()
+	df.Search(g.Node(0), nil, nil, nil)
+	dw := NewDepthFirst()
+	dw.Walk(g.Node(0), nil, false, DFSEvents{})
+	for _, n := range g.Nodes() {
+		if n.ID() == 20 {
+			c.Check(bf.PathTo(n), check.IsNil)
+			c.Check(df.PathTo(n), check.IsNil)
+			c.Check(dw.PathTo(n), check.IsNil)
+			continue
+		}
+		p := bf.PathTo(n)
+		checkPath(c, p, 0, n.ID())
+		c.Check(float64(len(p)-1), check.Equals, pt.dist[n.ID()])
+		checkPath(c, df.PathTo(n), 0, n.ID())
+		p = dw.PathTo(n)
+		checkPath(c, p, 0, n.ID())
+		c.Check(len(p)-1 <= 19, check.Equals, true)
+	}
+
+	bf.Reset()
+	c.Check(bf.PathTo(g.Node(1)), check.IsNil)
+	bf.Search(g.Node(19), nil, nil, nil)
+	checkPath(c, bf.PathTo(g.Node(1)), 19, 1)
+}
+
+func (s *S) TestSearchContext(c *check.C) {
+	g := Path(5 * contextCheck)
+	last := func(n Node) bool { return n.ID() == g.Order()-1 }
+
+	t, err := NewBreadthFirst().SearchContext(context.Background(), g.Node(0), nil, last, nil)
+	c.Check(err, check.IsNil)
+	c.Check(t.ID(), check.Equals, g.Order()-1)
+	t, err = NewDepthFirst().SearchContext(context.Background(), g.Node(0), nil, last, nil)
+	c.Check(err, check.IsNil)
+	c.Check(t.ID(), check.Equals, g.Order()-1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bf := NewBreadthFirst()
+	t, err = bf.SearchContext(ctx, g.Node(0), nil, last, nil)
+	c.Check(err, check.Equals, context.Canceled)
+	c.Check(t, check.IsNil)
+	c.Check(bf.Visited(g.Node(g.Order()-1)), check.Equals, false)
+	df := NewDepthFirst()
+	t, err = df.SearchContext(ctx, g.Node(0), nil, last, nil)
+	c.Check(err, check.Equals, context.Canceled)
+	c.Check(t, check.IsNil)
+	c.Check(df.Visited(g.Node(g.Order()-1)), check.Equals, false)
+
+	// Cancelled searches can be resumed.
+	t = bf.Search(g.Node(0), nil, last, nil)
+	c.Assert(t, check.NotNil)
+	c.Check(t.ID(), check.Equals, g.Order()-1)
+	checkPath(c, bf.PathTo(t), 0, g.Order()-1)
+	t, err = df.SearchContext(context.Background(), g.Node(0), nil, last, nil)
+	c.Check(err, check.IsNil)
+	c.Assert(t, check.NotNil)
+	c.Check(t.ID(), check.Equals, g.Order()-1)
+	checkPath(c, df.PathTo(t), 0, g.Order()-1)
+
+	// Resuming does not revisit the start node.
+	df.Reset()
+	var starts int
+	from0 := func(n Node) bool {
+		if n.ID() == 0 {
+			starts++
+		}
+		return false
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = df.SearchContext(ctx, g.Node(0), nil, from0, nil)
+	c.Check(err, check.Equals, context.Canceled)
+	t, err = df.SearchContext(context.Background(), g.Node(0), nil, from0, nil)
+	c.Check(err, check.IsNil)
+	c.Check(t, check.IsNil)
+	c.Check(starts, check.Equals, 1)
+}
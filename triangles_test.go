@@ -0,0 +1,67 @@
+// Copyright ©2012 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestTriangles(c *check.C) {
+	c.Check(Triangles(Complete(5)), check.Equals, 10)
+	c.Check(Triangles(Petersen()), check.Equals, 0)
+	c.Check(Triangles(NewUndirected()), check.Equals, 0)
+
+	// A triangle with a pendant node, parallel edges and a self-loop.
+	g := Cycle(3)
+	g.AddID(3)
+	g.ConnectByID(2, 3)
+	g.ConnectByID(0, 1)
+	g.ConnectByID(1, 2)
+	g.ConnectByID(2, 2)
+	c.Check(Triangles(g), check.Equals, 1)
+	c.Check(NodeTriangles(g), check.DeepEquals, map[int]int{0: 1, 1: 1, 2: 1, 3: 0})
+	c.Check(LocalClustering(g), check.DeepEquals, map[int]float64{0: 1, 1: 1, 2: 1. / 3, 3: 0})
+	c.Check(math.Abs(AverageClustering(g)-(1+1+1./3)/4) < 1e-12, check.Equals, true)
+	c.Check(Transitivity(g), check.Equals, 3./5)
+
+	c.Check(Transitivity(Star(4)), check.Equals, 0.)
+	c.Check(AverageClustering(NewUndirected()), check.Equals, 0.)
+}
+
+func (s *S) TestTrianglesRandom(c *check.C) {
+	// Check against brute force enumeration of triples.
+	g := GnP(30, 0.3, rand.New(rand.NewSource(1)))
+	nodes := g.Nodes()
+	adjacent := func(u, v Node) bool {
+		ok, _ := g.Connected(u, v)
+		return ok
+	}
+	var total int
+	per := make(map[int]int)
+	for _, n := range nodes {
+		per[n.ID()] = 0
+	}
+	for i, u := range nodes {
+		for j := i + 1; j < len(nodes); j++ {
+			v := nodes[j]
+			if !adjacent(u, v) {
+				continue
+			}
+			for _, w := range nodes[j+1:] {
+				if adjacent(u, w) && adjacent(v, w) {
+					total++
+					per[u.ID()]++
+					per[v.ID()]++
+					per[w.ID()]++
+				}
+			}
+		}
+	}
+	c.Check(Triangles(g), check.Equals, total)
+	c.Check(NodeTriangles(g), check.DeepEquals, per)
+}
@@ -14,12 +14,15 @@ var (
 	NodeDoesNotExist = errors.New("graph: node does not exist")
 	NodeIDOutOfRange = errors.New("graph: node id out of range")
 	EdgeDoesNotExist = errors.New("graph: edge does not exist")
+	EdgeExists       = errors.New("graph: edge exists")
+	EdgeIDOutOfRange = errors.New("graph: edge id out of range")
 )
 
 // An Undirected is a container for an undirected graph representation.
 type Undirected struct {
 	nodes, compNodes Nodes
 	edges, compEdges Edges
+	attrs            attributes
 }
 
 // NewUndirected creates a new empty Undirected graph.
@@ -224,8 +227,8 @@ func (g *Undirected) Merge(dst, src Node) error {
 
 // newEdge makes a new edge joining u and v with weight w. The ID chosen for the
 // edge is NextEdgeID().
-func (g *Undirected) newEdge(u, v Node) Edge {
-	e := newEdge(len(g.edges), len(g.compEdges), u, v)
+func (g *Undirected) newEdge(u, v Node, w float64) Edge {
+	e := newEdge(len(g.edges), len(g.compEdges), u, v, w)
 	g.edges = append(g.edges, e)
 	g.compEdges = append(g.compEdges, e)
 
@@ -233,11 +236,11 @@ func (g *Undirected) newEdge(u, v Node) Edge {
 }
 
 // newEdgeKeepID makes a new edge joining u and v with ID id and weight w.
-func (g *Undirected) newEdgeKeepID(id int, u, v Node) Edge {
+func (g *Undirected) newEdgeKeepID(id int, u, v Node, w float64) Edge {
 	if id < len(g.edges) && g.edges[id] != nil {
 		panic("graph: attempted to create a new edge with an existing ID")
 	}
-	e := newEdge(id, len(g.compEdges), u, v)
+	e := newEdge(id, len(g.compEdges), u, v, w)
 
 	switch {
 	case id == len(g.edges):
@@ -256,6 +259,17 @@ func (g *Undirected) newEdgeKeepID(id int, u, v Node) Edge {
 	return e
 }
 
+// connectIDs joins the existing nodes with IDs uid and vid with a new edge of weight w.
+func (g *Undirected) connectIDs(uid, vid int, w float64) Edge {
+	e := g.newEdge(g.nodes[uid], g.nodes[vid], w)
+	g.nodes[uid].add(e)
+	if vid != uid {
+		g.nodes[vid].add(e)
+	}
+
+	return e
+}
+
 // ConnectWith join nodes u and v with the provided edge. An error is returned if
 // either of the nodes does not exist.
 func (g *Undirected) ConnectWith(u, v Node, with Edge) error {
@@ -305,7 +319,7 @@ func (g *Undirected) Connect(u, v Node) (Edge, error) {
 		return nil, err
 	}
 
-	e := g.newEdge(u, v)
+	e := g.newEdge(u, v, 1)
 	u.add(e)
 	if v != u {
 		v.add(e)
@@ -331,7 +345,7 @@ func (g *Undirected) ConnectByID(uid, vid int) (int, error) {
 		return -1, err
 	}
 
-	e := g.newEdge(g.nodes[uid], g.nodes[vid])
+	e := g.newEdge(g.nodes[uid], g.nodes[vid], 1)
 	g.nodes[uid].add(e)
 	if vid != uid {
 		g.nodes[vid].add(e)